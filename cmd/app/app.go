@@ -2,11 +2,17 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net"
 	"net/http"
+	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -17,9 +23,9 @@ import (
 	"github.com/bluetuith-org/api-native/api/eventbus"
 	"github.com/bluetuith-org/api-native/platform"
 	"github.com/bluetuith-org/daemon/endpoints"
-	"github.com/danielgtaylor/huma/v2"
 	"github.com/pterm/pterm"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
 )
 
 const (
@@ -85,6 +91,95 @@ func cliApp() *cli.App {
 				},
 				Action: cmdOpenAPI,
 			},
+			{
+				Name:  "token",
+				Usage: "Manage the API tokens.",
+				Description: "Each API token is granted one or more scopes, which determine the operations it can call:\n" +
+					" read       - Fetch the properties and states of adapters and devices, and stream events.\n" +
+					" control    - Change adapter states, connect to and disconnect from devices, and control media players.\n" +
+					" pairing    - Pair with and remove devices.\n" +
+					" files      - Send files to devices.\n" +
+					" auth-reply - Reply to authorization requests.\n" +
+					"Once a token is created, all API calls (except for the health checks) require a token.",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "tokens",
+						Usage:       "The path of the file that holds the API tokens. Unless this option is set, token authentication is disabled if the file does not exist.",
+						DefaultText: "$XDG_CONFIG_HOME/bluerestd/tokens.json",
+						EnvVars:     []string{"BRESTD_TOKENS"},
+					},
+				},
+				Subcommands: []*cli.Command{
+					{
+						Name:  "create",
+						Usage: "Create a new token, and print it.",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:    "name",
+								Usage:   "A name that describes the token's user.",
+								Aliases: []string{"n"},
+							},
+							&cli.StringSliceFlag{
+								Name:    "scope",
+								Usage:   "A scope to grant to the token (read, control, pairing, files or auth-reply). Can be specified multiple times.",
+								Value:   cli.NewStringSlice(endpoints.ScopeRead),
+								Aliases: []string{"s"},
+							},
+						},
+						Action: cmdTokenCreate,
+					},
+					{
+						Name:   "list",
+						Usage:  "List all tokens.",
+						Action: cmdTokenList,
+					},
+					{
+						Name:      "revoke",
+						Usage:     "Revoke a token.",
+						ArgsUsage: "<token-id>",
+						Action:    cmdTokenRevoke,
+					},
+				},
+			},
+			{
+				Name:  "certs",
+				Usage: "Manage TLS certificates.",
+				Subcommands: []*cli.Command{
+					{
+						Name:        "generate",
+						Usage:       "Generate a self-signed CA, and a server and client certificate signed by it.",
+						Description: "The certificates and their keys are written in the PEM format, as 'ca.pem', 'server.pem' and 'client.pem', and 'ca-key.pem', 'server-key.pem' and 'client-key.pem'.\nThese are meant for quick setups, for example on a local network. The client certificate (and its key) is used by the API client.",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:        "dir",
+								Usage:       "The directory to write the certificates to.",
+								DefaultText: "$XDG_CONFIG_HOME/bluerestd/certs",
+								Aliases:     []string{"d"},
+							},
+							&cli.StringSliceFlag{
+								Name:  "host",
+								Usage: "A host name or IP address that the server certificate is valid for. Can be specified multiple times.",
+								Value: cli.NewStringSlice("localhost", "127.0.0.1", "::1"),
+							},
+							&cli.StringFlag{
+								Name:  "client-name",
+								Usage: "The common name of the client certificate, which is matched by the '--tls-client-allow' rules.",
+								Value: "client",
+							},
+							&cli.IntFlag{
+								Name:  "days",
+								Usage: "The number of days that the certificates are valid for.",
+								Value: 365,
+							},
+							&cli.BoolFlag{
+								Name:  "force",
+								Usage: "Overwrite existing certificates.",
+							},
+						},
+						Action: cmdCertsGenerate,
+					},
+				},
+			},
 			{
 				Name:        "launch",
 				Usage:       "Start the daemon and listen for incoming API requests.",
@@ -99,6 +194,43 @@ func cliApp() *cli.App {
 						Aliases:     []string{"t"},
 						EnvVars:     []string{"BRESTD_AUTHTIMEOUT"},
 					},
+					&cli.StringFlag{
+						Name:        "auth-rules",
+						Usage:       "The path of the file that holds the rules which automatically reply to authorization requests.\nThe rules are managed using the '/auth/rules' endpoints, and the file is created when the first rule is added.",
+						DefaultText: "$XDG_CONFIG_HOME/bluerestd/auth-rules.json",
+						EnvVars:     []string{"BRESTD_AUTH_RULES"},
+					},
+					&cli.StringFlag{
+						Name:    "auth-hook",
+						Usage:   "A command to run for each authorization request that requires a reply, which is answered by whichever replies first, the command or an API client.\nThe request is written to the standard input of the command as JSON, and the exit status accepts (0) or rejects (1) the request, with the first line of the output as the reason, or the PIN code or passkey to pair with.\nThe command is split on whitespace and is not run using a shell.",
+						EnvVars: []string{"BRESTD_AUTH_HOOK"},
+					},
+					&cli.StringFlag{
+						Name:        "auth-no-agent",
+						Usage:       "How authorization requests are handled while no agent is available to answer them (reject, accept or wait).\nAPI clients register as agents using the 'agent' parameter when subscribing to events, and the '--auth-hook' and '--auth-prompt' options are agents too.",
+						DefaultText: endpoints.AuthNoAgentReject,
+						Value:       endpoints.AuthNoAgentReject,
+						EnvVars:     []string{"BRESTD_AUTH_NO_AGENT"},
+					},
+					&cli.BoolFlag{
+						Name:    "auth-prompt",
+						Usage:   "Prompt for a reply to each authorization request on the terminal, which is answered by whichever replies first, the prompt or an API client.",
+						EnvVars: []string{"BRESTD_AUTH_PROMPT"},
+					},
+					&cli.StringFlag{
+						Name:        "log-format",
+						Usage:       "The format of the log, which is written to the standard error (text or json).",
+						DefaultText: "text",
+						Value:       "text",
+						EnvVars:     []string{"BRESTD_LOG_FORMAT"},
+					},
+					&cli.StringFlag{
+						Name:        "log-level",
+						Usage:       "The minimum level of log messages (debug, info, warn or error).",
+						DefaultText: "info",
+						Value:       "info",
+						EnvVars:     []string{"BRESTD_LOG_LEVEL"},
+					},
 					&cli.StringFlag{
 						Name:        "tcp-address",
 						Usage:       "The TCP address to listen on for API operations.",
@@ -115,6 +247,196 @@ func cliApp() *cli.App {
 						Aliases:     []string{"s"},
 						EnvVars:     []string{"BRESTD_SOCKET"},
 					},
+					&cli.StringFlag{
+						Name:        "unix-socket-mode",
+						Usage:       "The permissions of the UNIX socket, in octal. Clients need write permission to connect to the socket.",
+						DefaultText: "0600",
+						Value:       "0600",
+						EnvVars:     []string{"BRESTD_SOCKET_MODE"},
+					},
+					&cli.StringFlag{
+						Name:    "unix-socket-owner",
+						Usage:   "The owner of the UNIX socket, in the form 'user', 'user:group' or ':group', where the user and group are either names or numeric IDs.",
+						EnvVars: []string{"BRESTD_SOCKET_OWNER"},
+					},
+					&cli.StringFlag{
+						Name:    "tls-cert",
+						Usage:   "The path of the PEM-encoded certificate (chain) to serve HTTPS on the TCP address with. Requires the 'tls-key' option.",
+						EnvVars: []string{"BRESTD_TLS_CERT"},
+					},
+					&cli.StringFlag{
+						Name:    "tls-key",
+						Usage:   "The path of the PEM-encoded private key of the TLS certificate.",
+						EnvVars: []string{"BRESTD_TLS_KEY"},
+					},
+					&cli.StringFlag{
+						Name:    "tls-client-ca",
+						Usage:   "The path of a PEM-encoded CA bundle to verify client certificates against.\nClients that present a verified certificate are authorized using the 'tls-client-allow' rules (if any), instead of API tokens.",
+						EnvVars: []string{"BRESTD_TLS_CLIENT_CA"},
+					},
+					&cli.BoolFlag{
+						Name:    "tls-require-client-cert",
+						Usage:   "Reject TLS clients that do not present a certificate that is verified by the client CA bundle.",
+						EnvVars: []string{"BRESTD_TLS_REQUIRE_CLIENT_CERT"},
+					},
+					&cli.StringSliceFlag{
+						Name:    "tls-client-allow",
+						Usage:   "An allowlist rule for TLS client certificates, in the form 'scope=name,...', where scope is one of the token scopes, or '*' for all scopes, and each name is the common name of a client certificate.\nFor example: --tls-client-allow 'read=kiosk' --tls-client-allow '*=admin'.",
+						EnvVars: []string{"BRESTD_TLS_CLIENT_ALLOW"},
+					},
+					&cli.StringSliceFlag{
+						Name:        "allowed-host",
+						Usage:       "A host name or IP address that API requests may be sent to, as specified by the 'Host' header, to protect against DNS rebinding. Can be specified multiple times.\nIf not set, the loopback host names, the host name of the system, and the host of the TCP address are allowed.\nIf the TCP address does not specify a host, the addresses of all network interfaces are allowed.",
+						DefaultText: "localhost, 127.0.0.1, ::1, <host name>, <TCP address host>",
+						EnvVars:     []string{"BRESTD_ALLOWED_HOSTS"},
+					},
+					&cli.StringSliceFlag{
+						Name:    "cors-origin",
+						Usage:   "An origin (for example, 'https://dashboard.local:8080') that may call the API from a browser, or '*' for any origin. Can be specified multiple times.\nRequests from other origins are rejected, unless they are sent from the same origin as the API.",
+						EnvVars: []string{"BRESTD_CORS_ORIGINS"},
+					},
+					&cli.DurationFlag{
+						Name:        "cors-max-age",
+						Usage:       "The duration for which browsers may cache the CORS preflight responses.",
+						DefaultText: "10m",
+						Value:       10 * time.Minute,
+						EnvVars:     []string{"BRESTD_CORS_MAX_AGE"},
+					},
+					&cli.BoolFlag{
+						Name:    "strict-requests",
+						Usage:   "Reject state-changing form POST requests, which browsers can send from any web page without a CORS preflight.\nIn this case, such requests must send the 'X-Bluerestd-Request' (with any value) or the 'Authorization' header, or a JSON body. State-changing GET requests must always send one of these headers.",
+						EnvVars: []string{"BRESTD_STRICT_REQUESTS"},
+					},
+					&cli.StringSliceFlag{
+						Name:    "socket-allow",
+						Usage:   "An allowlist rule for UNIX socket clients, in the form 'scope=user,@group,...', where scope is one of the token scopes, or '*' for all scopes.\nIf any rules are set, clients connected to the UNIX socket are identified by their peer credentials (on Linux), and are only granted the scopes whose allowlist contains their user, or any of their groups, instead of using API tokens.\nFor example: --socket-allow 'read=@bluetooth' --socket-allow '*=root,1000'.",
+						EnvVars: []string{"BRESTD_SOCKET_ALLOW"},
+					},
+					&cli.IntFlag{
+						Name:        "event-buffer",
+						Usage:       "The number of recent events to keep in memory, which are replayed to reconnecting clients that send the 'Last-Event-ID' header.",
+						DefaultText: strconv.Itoa(endpoints.DefaultEventBufferSize),
+						Value:       endpoints.DefaultEventBufferSize,
+						EnvVars:     []string{"BRESTD_EVENTBUFFER"},
+					},
+					&cli.StringFlag{
+						Name:    "webhooks",
+						Usage:   "The path to a JSON file with an array of webhooks, that receive events as POST requests.\nEach webhook is defined as:\n {\"url\": \"<url>\", \"secret\": \"<HMAC-SHA256 secret>\", \"events\": [<event names>], \"actions\": [<event actions>], \"addresses\": [<addresses>], \"max_attempts\": <number>}.\nOnly 'url' is required.",
+						EnvVars: []string{"BRESTD_WEBHOOKS"},
+					},
+					&cli.StringFlag{
+						Name:        "webhook-queue",
+						Usage:       "The directory to store pending webhook deliveries in, which are retried until they are delivered.",
+						DefaultText: "<user cache directory>/bluerestd/webhooks",
+						EnvVars:     []string{"BRESTD_WEBHOOKQUEUE"},
+					},
+					&cli.StringFlag{
+						Name:    "mqtt-broker",
+						Usage:   "The address of an MQTT broker (for example, 'tcp://127.0.0.1:1883') to publish events and device states to, and to receive commands from.",
+						EnvVars: []string{"BRESTD_MQTT_BROKER"},
+					},
+					&cli.StringFlag{
+						Name:        "mqtt-topic-prefix",
+						Usage:       "The prefix of all MQTT topics.",
+						DefaultText: endpoints.DefaultMQTTTopicPrefix,
+						Value:       endpoints.DefaultMQTTTopicPrefix,
+						EnvVars:     []string{"BRESTD_MQTT_TOPICPREFIX"},
+					},
+					&cli.StringFlag{
+						Name:        "mqtt-client-id",
+						Usage:       "The client ID to connect to the MQTT broker with.",
+						DefaultText: "<mqtt-topic-prefix>",
+						EnvVars:     []string{"BRESTD_MQTT_CLIENTID"},
+					},
+					&cli.StringFlag{
+						Name:    "mqtt-username",
+						Usage:   "The username to authenticate with the MQTT broker.",
+						EnvVars: []string{"BRESTD_MQTT_USERNAME"},
+					},
+					&cli.StringFlag{
+						Name:    "mqtt-password",
+						Usage:   "The password to authenticate with the MQTT broker.",
+						EnvVars: []string{"BRESTD_MQTT_PASSWORD"},
+					},
+					&cli.StringFlag{
+						Name:    "mqtt-token",
+						Usage:   "The API token that authorizes the commands received from the MQTT broker, which requires the 'control' scope.\nIf API tokens are enabled and no token is set, all commands are rejected.",
+						EnvVars: []string{"BRESTD_MQTT_TOKEN"},
+					},
+					&cli.BoolFlag{
+						Name:    "mqtt-homeassistant",
+						Usage:   "Publish Home Assistant MQTT discovery configurations for all paired devices.",
+						EnvVars: []string{"BRESTD_MQTT_HOMEASSISTANT"},
+					},
+					&cli.StringFlag{
+						Name:        "mqtt-homeassistant-prefix",
+						Usage:       "The Home Assistant MQTT discovery prefix.",
+						DefaultText: endpoints.DefaultHomeAssistantPrefix,
+						Value:       endpoints.DefaultHomeAssistantPrefix,
+						EnvVars:     []string{"BRESTD_MQTT_HOMEASSISTANTPREFIX"},
+					},
+					&cli.StringFlag{
+						Name:    "journal",
+						Usage:   "The directory to record all events and mutating API calls in, which can be queried using the '/journal' endpoint.",
+						EnvVars: []string{"BRESTD_JOURNAL"},
+					},
+					&cli.Int64Flag{
+						Name:        "journal-max-size",
+						Usage:       "The maximum size of a journal file (in bytes), after which it is rotated.",
+						DefaultText: strconv.Itoa(endpoints.DefaultJournalMaxSize),
+						Value:       endpoints.DefaultJournalMaxSize,
+						EnvVars:     []string{"BRESTD_JOURNAL_MAXSIZE"},
+					},
+					&cli.IntFlag{
+						Name:        "journal-max-files",
+						Usage:       "The number of rotated journal files to keep.",
+						DefaultText: strconv.Itoa(endpoints.DefaultJournalMaxFiles),
+						Value:       endpoints.DefaultJournalMaxFiles,
+						EnvVars:     []string{"BRESTD_JOURNAL_MAXFILES"},
+					},
+					&cli.StringFlag{
+						Name:    "grpc-address",
+						Usage:   "The address to serve the gRPC API on, which is either a TCP address (for example, '127.0.0.1:50051'), or a UNIX socket path prefixed with 'unix:'.\nIf the 'tls-cert' option is set, TCP clients must connect with TLS. The service definition is available in 'rpc/bluerestd.proto'.",
+						EnvVars: []string{"BRESTD_GRPCADDR"},
+					},
+					&cli.StringFlag{
+						Name:    "jsonrpc-socket",
+						Usage:   "The UNIX socket path to serve the JSON-RPC 2.0 API on, with one message per line.\nEach API operation is a method named after its operation ID, and events are sent as notifications after calling 'subscribe'. For example, using 'socat':\n echo '{\"jsonrpc\": \"2.0\", \"id\": 1, \"method\": \"adapters\"}' | socat - UNIX-CONNECT:/tmp/bluerestd-rpc.sock",
+						EnvVars: []string{"BRESTD_JSONRPCSOCKET"},
+					},
+					&cli.StringFlag{
+						Name:        "tokens",
+						Usage:       "The path of the file that holds the API tokens. Unless this option is set, token authentication is disabled if the file does not exist.",
+						DefaultText: "$XDG_CONFIG_HOME/bluerestd/tokens.json",
+						EnvVars:     []string{"BRESTD_TOKENS"},
+					},
+					&cli.StringFlag{
+						Name:    "trace-otlp-endpoint",
+						Usage:   "The URL of an OTLP/HTTP collector to export traces of API requests and Bluetooth operations to, for example 'http://localhost:4318'.\nThe standard 'OTEL_EXPORTER_OTLP_*' environment variables are also respected.",
+						EnvVars: []string{"BRESTD_TRACE_OTLP_ENDPOINT"},
+					},
+					&cli.StringFlag{
+						Name:    "trace-file",
+						Usage:   "The path of a file to append traces of API requests and Bluetooth operations to, as JSON.",
+						EnvVars: []string{"BRESTD_TRACE_FILE"},
+					},
+					&cli.DurationFlag{
+						Name:        "presence-timeout",
+						Usage:       "The duration after which a device that has not been seen is considered to have left.",
+						DefaultText: endpoints.DefaultPresenceTimeout.String(),
+						Value:       endpoints.DefaultPresenceTimeout,
+						EnvVars:     []string{"BRESTD_PRESENCE_TIMEOUT"},
+					},
+					&cli.DurationFlag{
+						Name:    "presence-hysteresis",
+						Usage:   "The duration for which a device has to be seen continuously, before it is considered to have arrived.",
+						EnvVars: []string{"BRESTD_PRESENCE_HYSTERESIS"},
+					},
+					&cli.IntFlag{
+						Name:    "presence-min-rssi",
+						Usage:   "The minimum signal strength (in dBm) for a device to be considered seen, for example '-80'.",
+						EnvVars: []string{"BRESTD_PRESENCE_MINRSSI"},
+					},
 				},
 				Action: cmdStart,
 			},
@@ -124,6 +446,11 @@ func cliApp() *cli.App {
 				return
 			}
 
+			if !pterm.Output {
+				slog.Error("Exited with an error", "error", err)
+				return
+			}
+
 			cmdErr := &cmdError{}
 			if errors.As(err, cmdErr) {
 				if cmdErr.err != nil {
@@ -141,6 +468,10 @@ func cmdStart(cliCtx *cli.Context) error {
 		return errors.New("Only one of '--tcp-address' or '--unix-socket' must be specified.")
 	}
 
+	if err := setupLogging(cliCtx.String("log-format"), cliCtx.String("log-level")); err != nil {
+		return err
+	}
+
 	spinner := infoSpinner("Starting session")
 
 	tcpaddr := cliCtx.String("tcp-address")
@@ -150,22 +481,83 @@ func cmdStart(cliCtx *cli.Context) error {
 		proto, addr = "unix", sockpath
 	}
 
-	listener, err := net.Listen(proto, addr)
+	listener, err := listen(cliCtx, proto, addr)
 	if err != nil {
 		return newCmdError(spinner, fmt.Errorf("Cannot listen on %s '%s': %w", proto, addr, err))
 	}
 
-	session, collection, err := newSession(cliCtx)
+	opts, err := newOptions(cliCtx)
 	if err != nil {
+		listener.Close()
+		return newCmdError(spinner, err)
+	}
+
+	// fail releases the listener and the options, if the daemon cannot be started.
+	fail := func(err error) error {
+		listener.Close()
+		closeOptions(opts)
+		if opts.Tracing != nil {
+			opts.Tracing.Close()
+		}
+
 		return newCmdError(spinner, err)
 	}
 
+	authConfig := endpoints.AuthorizerConfig{Rules: opts.AuthRules, NoAgent: cliCtx.String("auth-no-agent")}
+	if !slices.Contains(endpoints.AuthNoAgentPolicies, authConfig.NoAgent) {
+		return fail(fmt.Errorf(
+			"Invalid value '%s' for '--auth-no-agent', must be one of: %s", authConfig.NoAgent, strings.Join(endpoints.AuthNoAgentPolicies, ", "),
+		))
+	}
+	if command := cliCtx.String("auth-hook"); command != "" {
+		hook, err := endpoints.NewAuthHook(command)
+		if err != nil {
+			return fail(err)
+		}
+
+		authConfig.Agents = append(authConfig.Agents, hook)
+	}
+	var prompt *authPrompt
+	if cliCtx.Bool("auth-prompt") {
+		if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+			return fail(errors.New("The '--auth-prompt' option requires the daemon to run in a terminal."))
+		}
+
+		prompt = newAuthPrompt()
+		authConfig.Agents = append(authConfig.Agents, prompt)
+	}
+
+	session, collection, info, err := newSession(cliCtx, authConfig)
+	if err != nil {
+		return fail(err)
+	}
+	if prompt != nil {
+		prompt.start(session)
+	}
+
+	info.Version, info.Revision = version, revision
+	opts.Info = info
+
 	router := http.NewServeMux()
-	endpoints.Register(router, session, collection)
+	_, start := endpoints.Register(router, session, collection, opts)
+	start()
+
+	var handler http.Handler = endpoints.AccessLog(opts.Origins.Handler(router))
+	if opts.Tracing != nil {
+		handler = opts.Tracing.Handler(handler)
+	}
 
-	err = serve(listener, router, spinner)
+	err = serve(listener, handler, spinner, prompt != nil)
+	closeOptions(opts)
 	if e := session.Stop(); e != nil {
 		err = errors.Join(err, fmt.Errorf("Session shutdown error: %w", e))
+	} else {
+		slog.Info("Session stopped")
+	}
+	if opts.Tracing != nil {
+		if e := opts.Tracing.Close(); e != nil {
+			err = errors.Join(err, fmt.Errorf("Tracing shutdown error: %w", e))
+		}
 	}
 
 	if err == nil {
@@ -177,10 +569,7 @@ func cmdStart(cliCtx *cli.Context) error {
 
 func cmdOpenAPI(cliCtx *cli.Context) error {
 	oldFormat := false
-	apifn := func() *huma.OpenAPI {
-		api := endpoints.Register(http.NewServeMux(), nil, ac.MergedCollection())
-		return api.OpenAPI()
-	}
+	apifn := endpoints.OpenAPI
 
 	var (
 		b   []byte
@@ -225,17 +614,291 @@ Done:
 	return err
 }
 
-func newSession(cliCtx *cli.Context) (bluetooth.Session, ac.Collection, error) {
+func newOptions(cliCtx *cli.Context) (opts endpoints.Options, err error) {
+	defer func() {
+		if err != nil {
+			closeOptions(opts)
+		}
+	}()
+
+	opts = endpoints.Options{
+		EventBufferSize: cliCtx.Int("event-buffer"),
+		Presence: endpoints.NewPresenceTracker(endpoints.PresenceConfig{
+			Timeout:    cliCtx.Duration("presence-timeout"),
+			Hysteresis: cliCtx.Duration("presence-hysteresis"),
+			MinRSSI:    cliCtx.Int("presence-min-rssi"),
+		}),
+	}
+
+	if path := cliCtx.String("webhooks"); path != "" {
+		queueDir := cliCtx.String("webhook-queue")
+		if queueDir == "" {
+			cacheDir, err := os.UserCacheDir()
+			if err != nil {
+				return opts, fmt.Errorf("Cannot determine the webhook queue directory: %w", err)
+			}
+
+			queueDir = filepath.Join(cacheDir, "bluerestd", "webhooks")
+		}
+
+		webhooks, err := endpoints.NewWebhooks(path, queueDir)
+		if err != nil {
+			return opts, err
+		}
+
+		opts.Webhooks = webhooks
+	}
+
+	if broker := cliCtx.String("mqtt-broker"); broker != "" {
+		bridge, err := endpoints.NewMQTTBridge(endpoints.MQTTConfig{
+			Broker:      broker,
+			ClientID:    cliCtx.String("mqtt-client-id"),
+			Username:    cliCtx.String("mqtt-username"),
+			Password:    cliCtx.String("mqtt-password"),
+			TopicPrefix: cliCtx.String("mqtt-topic-prefix"),
+			Token:       cliCtx.String("mqtt-token"),
+
+			HomeAssistant:       cliCtx.Bool("mqtt-homeassistant"),
+			HomeAssistantPrefix: cliCtx.String("mqtt-homeassistant-prefix"),
+		})
+		if err != nil {
+			return opts, err
+		}
+
+		opts.MQTT = bridge
+	}
+
+	if dir := cliCtx.String("journal"); dir != "" {
+		journal, err := endpoints.NewJournal(dir, cliCtx.Int64("journal-max-size"), cliCtx.Int("journal-max-files"))
+		if err != nil {
+			return opts, err
+		}
+
+		opts.Journal = journal
+	}
+
+	if addr := cliCtx.String("grpc-address"); addr != "" {
+		proto := "tcp"
+		if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+			proto, addr = "unix", path
+		}
+
+		var listener net.Listener
+		if proto == "unix" {
+			listener, err = listen(cliCtx, proto, addr)
+		} else {
+			listener, err = listenGRPC(cliCtx, addr)
+		}
+		if err != nil {
+			return opts, fmt.Errorf("Cannot listen on %s '%s' for gRPC: %w", proto, addr, err)
+		}
+
+		opts.GRPC = endpoints.NewGRPCServer(listener)
+	}
+
+	if path := cliCtx.String("jsonrpc-socket"); path != "" {
+		listener, err := listen(cliCtx, "unix", path)
+		if err != nil {
+			return opts, fmt.Errorf("Cannot listen on unix '%s' for JSON-RPC: %w", path, err)
+		}
+
+		opts.JSONRPC = endpoints.NewJSONRPCServer(listener)
+	}
+
+	rulesPath, err := configFile(cliCtx, "auth-rules", "auth-rules.json")
+	if err != nil {
+		return opts, err
+	}
+
+	rules, err := endpoints.OpenAuthRules(rulesPath)
+	if err != nil {
+		return opts, err
+	}
+	opts.AuthRules = rules
+
+	path, err := tokenFile(cliCtx)
+	if err != nil {
+		return opts, err
+	}
+	if _, err := os.Stat(path); err == nil || cliCtx.IsSet("tokens") {
+		tokens, err := endpoints.OpenTokenStore(path)
+		if err != nil {
+			return opts, err
+		}
+
+		opts.Tokens = tokens
+	} else {
+		slog.Warn("Token authentication is disabled, since no API tokens have been created", "tokens", path)
+	}
+
+	hosts := cliCtx.StringSlice("allowed-host")
+	if len(hosts) == 0 && cliCtx.String("unix-socket") == "" {
+		hosts = defaultAllowedHosts(cliCtx.String("tcp-address"))
+	}
+
+	origins, err := endpoints.NewOriginPolicy(endpoints.OriginConfig{
+		AllowedHosts:   hosts,
+		AllowedOrigins: cliCtx.StringSlice("cors-origin"),
+		MaxAge:         cliCtx.Duration("cors-max-age"),
+		StrictRequests: cliCtx.Bool("strict-requests"),
+	})
+	if err != nil {
+		return opts, err
+	}
+	opts.Origins = origins
+
+	if rules := cliCtx.StringSlice("socket-allow"); len(rules) > 0 {
+		peers, err := endpoints.NewPeerPolicy(rules)
+		if err != nil {
+			return opts, err
+		}
+
+		opts.Peers = peers
+	}
+
+	if rules := cliCtx.StringSlice("tls-client-allow"); len(rules) > 0 {
+		if !cliCtx.IsSet("tls-client-ca") {
+			return opts, errors.New("The '--tls-client-allow' option requires the '--tls-client-ca' option.")
+		}
+
+		certs, err := endpoints.NewCertPolicy(rules)
+		if err != nil {
+			return opts, err
+		}
+
+		opts.Certs = certs
+	}
+
+	if endpoint, file := cliCtx.String("trace-otlp-endpoint"), cliCtx.String("trace-file"); endpoint != "" || file != "" {
+		tracing, err := endpoints.NewTracing(endpoints.TracingConfig{
+			OTLPEndpoint: endpoint,
+			File:         file,
+		})
+		if err != nil {
+			return opts, err
+		}
+
+		opts.Tracing = tracing
+	}
+
+	return opts, nil
+}
+
+// closeOptions stops the services and closes the listeners of the options.
+func closeOptions(opts endpoints.Options) {
+	if opts.Presence != nil {
+		opts.Presence.Close()
+	}
+	if opts.Webhooks != nil {
+		opts.Webhooks.Close()
+	}
+	if opts.MQTT != nil {
+		opts.MQTT.Close()
+	}
+	if opts.Journal != nil {
+		opts.Journal.Close()
+	}
+	if opts.GRPC != nil {
+		opts.GRPC.Close()
+	}
+	if opts.JSONRPC != nil {
+		opts.JSONRPC.Close()
+	}
+}
+
+// defaultAllowedHosts returns the host names and IP addresses that requests to the TCP address
+// may be sent to, which are the loopback host names, the host name of the system, and the host
+// of the address. If the address is unspecified, the addresses of all interfaces are allowed.
+func defaultAllowedHosts(addr string) []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if hostname, err := os.Hostname(); err == nil {
+		hosts = append(hosts, hostname, hostname+".local")
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return hosts
+	}
+
+	if ip := net.ParseIP(host); host != "" && (ip == nil || !ip.IsUnspecified()) {
+		return append(hosts, host)
+	}
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return hosts
+	}
+
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok {
+			hosts = append(hosts, ipnet.IP.String())
+		}
+	}
+
+	return hosts
+}
+
+// listen listens on the TCP address or UNIX socket path. If TLS is enabled, the TCP listener
+// serves TLS connections, and UNIX sockets are created with the configured permissions and owner.
+func listen(cliCtx *cli.Context, proto, addr string) (net.Listener, error) {
+	if proto != "unix" {
+		config, err := newTLSConfig(cliCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		listener, err := net.Listen(proto, addr)
+		if err != nil || config == nil {
+			return listener, err
+		}
+
+		return tls.NewListener(listener, config), nil
+	}
+
+	mode, err := parseSocketMode(cliCtx.String("unix-socket-mode"))
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := parseSocketOwner(cliCtx.String("unix-socket-owner"))
+	if err != nil {
+		return nil, err
+	}
+
+	return listenUnix(addr, mode, owner)
+}
+
+// listenGRPC listens on the TCP address for gRPC clients. If TLS is enabled, the listener serves
+// TLS connections with the TLS configuration of the API, and negotiates HTTP/2, which gRPC clients require.
+func listenGRPC(cliCtx *cli.Context, addr string) (net.Listener, error) {
+	config, err := newTLSConfig(cliCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil || config == nil {
+		return listener, err
+	}
+
+	config.NextProtos = []string{"h2"}
+
+	return tls.NewListener(listener, config), nil
+}
+
+func newSession(cliCtx *cli.Context, authConfig endpoints.AuthorizerConfig) (bluetooth.Session, ac.Collection, endpoints.DaemonInfo, error) {
 	eventbus.DisableEvents()
 
 	cfg := config.New()
 	cfg.AuthTimeout = cliCtx.Duration("auth-timeout") * time.Second
 
 	session, pinfo := platform.Session()
-	collection, err := session.Start(endpoints.NewAuthorizer(), cfg)
+	collection, err := session.Start(endpoints.NewAuthorizer(authConfig), cfg)
 	if err != nil {
-		return nil, collection, fmt.Errorf("Session initialization error: %w", err)
+		return nil, collection, endpoints.DaemonInfo{}, fmt.Errorf("Session initialization error: %w", err)
 	}
+	slog.Info("Session started", "stack", pinfo.Stack.String(), "os", pinfo.OS)
+
 	if cerrs, ok := collection.Errors.Exists(); ok {
 		cstyle := pterm.NewStyle(pterm.FgLightYellow, pterm.Bold)
 		estyle := pterm.NewStyle(pterm.FgRed, pterm.Bold, pterm.Underscore)
@@ -244,6 +907,8 @@ func newSession(cliCtx *cli.Context) (bluetooth.Session, ac.Collection, error) {
 
 		nodes := make([]pterm.TreeNode, 0, len(cerrs))
 		for c, err := range cerrs {
+			slog.Warn("Feature not available", "feature", c.String(), "error", err.Err)
+
 			nodes = append(nodes, pterm.TreeNode{
 				Text: cstyle.Sprintf("'%s' -> %s", c.String(), estyle.Sprint(err.Err.Error())),
 			})
@@ -256,10 +921,10 @@ func newSession(cliCtx *cli.Context) (bluetooth.Session, ac.Collection, error) {
 	printInfo("Bluetooth stack: %s, OS: %s", pinfo.Stack.String(), pinfo.OS)
 	newline()
 
-	return session, collection, nil
+	return session, collection, endpoints.DaemonInfo{Stack: pinfo.Stack.String(), OS: pinfo.OS}, nil
 }
 
-func serve(listener net.Listener, router *http.ServeMux, spinner *pterm.SpinnerPrinter) error {
+func serve(listener net.Listener, handler http.Handler, spinner *pterm.SpinnerPrinter, prompts bool) error {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
@@ -267,7 +932,9 @@ func serve(listener net.Listener, router *http.ServeMux, spinner *pterm.SpinnerP
 	errchan := make(chan error, 1)
 	server := &http.Server{
 		BaseContext: func(l net.Listener) context.Context { return ctx },
-		Handler:     router,
+		ConnContext: endpoints.ConnContext,
+		Handler:     handler,
+		ErrorLog:    slog.NewLogLogger(slog.Default().Handler(), slog.LevelError),
 	}
 
 	go func() {
@@ -288,6 +955,12 @@ func serve(listener net.Listener, router *http.ServeMux, spinner *pterm.SpinnerP
 		newline()
 
 		updateSpinner(spinner, "Listening on %s %s ...", cstyle(cstr), astyle(astr))
+		slog.Info("Listening", "network", listener.Addr().Network(), "address", astr)
+
+		// The spinner is stopped, so that it does not overwrite the authorization prompts.
+		if prompts {
+			spinner.Stop()
+		}
 
 		// Start the server!
 		if err := server.Serve(listener); err != nil {
@@ -309,6 +982,7 @@ func serve(listener net.Listener, router *http.ServeMux, spinner *pterm.SpinnerP
 
 	updateSpinner(spinner, strings.Repeat(" ", len(spinner.Text)))
 	updateSpinner(spinner, "Exiting, please wait...")
+	slog.Info("Shutting down")
 
 	if e := server.Shutdown(ctx); e != nil {
 		err = errors.Join(err, fmt.Errorf("Server shutdown error: %w", e))
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// certFiles are the names of the files that are written by the 'certs generate' command.
+var certFiles = []string{
+	"ca.pem", "ca-key.pem",
+	"server.pem", "server-key.pem",
+	"client.pem", "client-key.pem",
+}
+
+// certificate holds a generated certificate and its private key.
+type certificate struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func cmdCertsGenerate(cliCtx *cli.Context) error {
+	dir, err := certsDir(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	if !cliCtx.Bool("force") {
+		for _, name := range certFiles {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return fmt.Errorf("The file '%s' already exists, use '--force' to overwrite it.", filepath.Join(dir, name))
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("Cannot create the certificate directory: %w", err)
+	}
+
+	validity := time.Duration(cliCtx.Int("days")) * 24 * time.Hour
+	if validity <= 0 {
+		return errors.New("The validity must be at least one day.")
+	}
+
+	ca, err := newCertificate(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: "bluerestd CA"},
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, validity, nil)
+	if err != nil {
+		return err
+	}
+
+	server := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "bluerestd"},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range cliCtx.StringSlice("host") {
+		if ip := net.ParseIP(host); ip != nil {
+			server.IPAddresses = append(server.IPAddresses, ip)
+		} else {
+			server.DNSNames = append(server.DNSNames, host)
+		}
+	}
+
+	serverCert, err := newCertificate(server, validity, &ca)
+	if err != nil {
+		return err
+	}
+
+	clientCert, err := newCertificate(&x509.Certificate{
+		Subject:     pkix.Name{CommonName: cliCtx.String("client-name")},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, validity, &ca)
+	if err != nil {
+		return err
+	}
+
+	for i, c := range []certificate{ca, serverCert, clientCert} {
+		if err := writeCertificate(dir, certFiles[i*2], certFiles[i*2+1], c); err != nil {
+			return err
+		}
+	}
+
+	printInfo("Generated the certificates in '%s'.", dir)
+	printNote("Launch the daemon with: --tls-cert %s --tls-key %s --tls-client-ca %s --tls-client-allow '*=%s'",
+		filepath.Join(dir, "server.pem"), filepath.Join(dir, "server-key.pem"),
+		filepath.Join(dir, "ca.pem"), cliCtx.String("client-name"),
+	)
+
+	return nil
+}
+
+// newCertificate generates a key, and creates a certificate from the template which is
+// signed by the parent. If parent is nil, the certificate is self-signed.
+func newCertificate(template *x509.Certificate, validity time.Duration, parent *certificate) (certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return certificate{}, err
+	}
+
+	template.SerialNumber = serial
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(validity)
+
+	issuer, signer := template, crypto.Signer(key)
+	if parent != nil {
+		issuer, signer = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, key.Public(), signer)
+	if err != nil {
+		return certificate{}, fmt.Errorf("Cannot create the certificate '%s': %w", template.Subject.CommonName, err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return certificate{}, err
+	}
+
+	return certificate{cert: cert, key: key}, nil
+}
+
+// writeCertificate writes the certificate and its private key to the directory in the PEM format.
+func writeCertificate(dir, certName, keyName string, c certificate) error {
+	key, err := x509.MarshalPKCS8PrivateKey(c.key)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range []struct {
+		name  string
+		block *pem.Block
+		mode  os.FileMode
+	}{
+		{certName, &pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}, 0o644},
+		{keyName, &pem.Block{Type: "PRIVATE KEY", Bytes: key}, 0o600},
+	} {
+		path := filepath.Join(dir, file.name)
+		if err := os.WriteFile(path, pem.EncodeToMemory(file.block), file.mode); err != nil {
+			return fmt.Errorf("Cannot write '%s': %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// certsDir returns the directory to write the certificates to, which is in the
+// user's configuration directory unless the 'dir' option is set.
+func certsDir(cliCtx *cli.Context) (string, error) {
+	if dir := cliCtx.String("dir"); dir != "" {
+		return dir, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("Cannot determine the certificate directory: %w", err)
+	}
+
+	return filepath.Join(configDir, "bluerestd", "certs"), nil
+}
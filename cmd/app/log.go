@@ -0,0 +1,42 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/pterm/pterm"
+	"golang.org/x/term"
+)
+
+// setupLogging installs the default structured logger, which writes to the standard error
+// in the provided format ('text' or 'json') and at the provided level. The pretty
+// output is only printed if the standard output is an interactive terminal.
+func setupLogging(format, level string) error {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return fmt.Errorf("Invalid log level: %s", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+
+	var handler slog.Handler
+	switch format {
+	case "text":
+		handler = slog.NewTextHandler(os.Stderr, opts)
+
+	case "json":
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+
+	default:
+		return fmt.Errorf("Invalid log format: %s", format)
+	}
+
+	slog.SetDefault(slog.New(handler))
+
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		pterm.DisableOutput()
+	}
+
+	return nil
+}
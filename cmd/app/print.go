@@ -1,7 +1,6 @@
 package app
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/pterm/pterm"
@@ -38,7 +37,7 @@ func printNote(fmt string, s ...any) {
 }
 
 func newline() {
-	fmt.Println()
+	pterm.Println()
 }
 
 func updateSpinner(spinner *pterm.SpinnerPrinter, fmt string, s ...any) {
@@ -0,0 +1,254 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"atomicgo.dev/keyboard"
+	"atomicgo.dev/keyboard/keys"
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+	"github.com/bluetuith-org/daemon/endpoints"
+	"github.com/pterm/pterm"
+)
+
+// authPromptReason is the reason of the reply to requests that are rejected at the prompt.
+const authPromptReason = "The request was rejected on the daemon's terminal."
+
+// authPromptDismissDelay is the duration to wait for a dismissed prompt to read the simulated key press.
+const authPromptDismissDelay = 100 * time.Millisecond
+
+// authPrompt prompts for a reply to each authorization request on the terminal that the
+// daemon runs in. Since only one prompt can be shown at a time, the other requests wait
+// until the current prompt is answered or dismissed.
+//
+// A prompt is dismissed by simulating the 'Enter' key, if the request is answered by an
+// API client first, or if it times out, and its result is then ignored.
+type authPrompt struct {
+	mu sync.Mutex
+
+	sessionLock sync.Mutex
+	session     bluetooth.Session
+}
+
+// newAuthPrompt returns a prompt for authorization requests.
+func newAuthPrompt() *authPrompt {
+	return &authPrompt{}
+}
+
+// start sets the session, which is used to show the names of the devices.
+func (p *authPrompt) start(session bluetooth.Session) {
+	p.sessionLock.Lock()
+	defer p.sessionLock.Unlock()
+
+	p.session = session
+}
+
+// Answer prompts for the reply to the request, until a valid reply is entered,
+// or until the request is no longer pending.
+func (p *authPrompt) Answer(ctx context.Context, data endpoints.AuthRequestEvent, reply func(endpoints.AuthReply) error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	newline()
+	pterm.DefaultSection.WithLevel(2).Printfln("Authorization request #%d", data.ID)
+	pterm.Println(p.details(data))
+
+	for {
+		r, ok := p.prompt(ctx, data)
+		if !ok {
+			break
+		}
+
+		err := reply(r)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+
+		pterm.Error.Println(err)
+	}
+
+	if ctx.Err() != nil {
+		pterm.Info.Printfln("Authorization request #%d is no longer pending.", data.ID)
+	}
+}
+
+// prompt prompts for the reply to the request, or for the value that is entered
+// on the device for 'request-pincode' and 'request-passkey' requests.
+func (p *authPrompt) prompt(ctx context.Context, data endpoints.AuthRequestEvent) (endpoints.AuthReply, bool) {
+	pairingType := ""
+	if data.PairingParams != nil {
+		pairingType = data.PairingParams.PairingType
+	}
+
+	switch pairingType {
+	case "request-pincode", "request-passkey":
+		label := "PIN code"
+		if pairingType == "request-passkey" {
+			label = "Passkey"
+		}
+
+		for {
+			value, ok := showPrompt(ctx, func(interrupt func()) (string, error) {
+				return pterm.DefaultInteractiveTextInput.
+					WithOnInterruptFunc(interrupt).
+					Show(label + " (leave empty to reject)")
+			})
+			value = strings.TrimSpace(value)
+
+			switch {
+			case !ok:
+				return endpoints.AuthReply{}, false
+
+			case value == "":
+				return endpoints.AuthReply{Reason: authPromptReason}, true
+
+			case pairingType == "request-pincode":
+				return endpoints.AuthReply{Accept: true, Pincode: value}, true
+			}
+
+			passkey, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				pterm.Error.Printfln("Invalid passkey '%s', it must be a number.", value)
+				continue
+			}
+
+			pk := uint32(passkey)
+
+			return endpoints.AuthReply{Accept: true, Passkey: &pk}, true
+		}
+	}
+
+	accepted, ok := showPrompt(ctx, func(interrupt func()) (bool, error) {
+		return pterm.DefaultInteractiveConfirm.
+			WithOnInterruptFunc(interrupt).
+			Show("Accept the request?")
+	})
+	if !ok {
+		return endpoints.AuthReply{}, false
+	}
+
+	if !accepted {
+		return endpoints.AuthReply{Reason: authPromptReason}, true
+	}
+
+	return endpoints.AuthReply{Accept: true}, true
+}
+
+// details describes the request, with the device, passkey and file details.
+func (p *authPrompt) details(data endpoints.AuthRequestEvent) string {
+	var rows [][]string
+
+	switch {
+	case data.PairingParams != nil:
+		params := data.PairingParams
+
+		rows = append(rows,
+			[]string{"Type", "pairing (" + params.PairingType + ")"},
+			[]string{"Device", p.device(params.Address)},
+		)
+		if params.PairingType == "confirm-passkey" || params.PairingType == "display-passkey" {
+			rows = append(rows, []string{"Passkey", fmt.Sprintf("%06d", params.Passkey)})
+		}
+		if params.PairingType == "display-pincode" {
+			rows = append(rows, []string{"PIN code", params.Pincode})
+		}
+		if params.ServiceUUID != nil {
+			rows = append(rows, []string{"Service", params.ServiceUUID.String()})
+		}
+
+	case data.TransferParams != nil:
+		props := data.TransferParams.FileProperties
+
+		rows = append(rows,
+			[]string{"Type", "transfer"},
+			[]string{"Device", p.device(props.Address)},
+			[]string{"File", props.Name},
+			[]string{"Size", strconv.FormatUint(props.Size, 10) + " bytes"},
+			[]string{"Path", data.TransferParams.Path},
+		)
+	}
+
+	var details strings.Builder
+	for _, row := range rows {
+		details.WriteString(pterm.Bold.Sprint(row[0]+": ") + row[1] + "\n")
+	}
+
+	return strings.TrimSuffix(details.String(), "\n")
+}
+
+// device describes the device with its name, if it is known, and its address.
+func (p *authPrompt) device(address bluetooth.MacAddress) string {
+	p.sessionLock.Lock()
+	session := p.session
+	p.sessionLock.Unlock()
+
+	if session != nil {
+		if properties, err := session.Device(address).Properties(); err == nil && properties.Name != "" {
+			return properties.Name + " (" + address.String() + ")"
+		}
+	}
+
+	return address.String()
+}
+
+// showPrompt shows an interactive prompt until it is answered, or until the context is done,
+// in which case the prompt is dismissed. It reports whether the prompt was answered, and
+// not dismissed or interrupted.
+func showPrompt[T any](ctx context.Context, show func(interrupt func()) (T, error)) (T, bool) {
+	simulated := make(chan bool, 1)
+	shown, dismissed := make(chan struct{}), make(chan struct{})
+
+	go func() {
+		defer close(dismissed)
+
+		select {
+		case <-ctx.Done():
+			simulated <- true
+			keyboard.SimulateKeyPress(keys.Enter)
+
+		case <-shown:
+			simulated <- false
+		}
+	}()
+
+	var interrupted bool
+	value, err := show(func() {
+		interrupted = true
+		interruptDaemon()
+	})
+	close(shown)
+
+	// If the prompt was answered at the same time as it was dismissed, the simulated key press is
+	// not read by the prompt. It is read here instead, so that it does not answer the next prompt.
+	if <-simulated {
+		select {
+		case <-dismissed:
+		case <-time.After(authPromptDismissDelay):
+			keyboard.Listen(func(keys.Key) (bool, error) {
+				return true, nil
+			})
+			<-dismissed
+		}
+	}
+
+	return value, err == nil && !interrupted && ctx.Err() == nil
+}
+
+// interruptDaemon stops the daemon when Ctrl+C is pressed at a prompt, since the
+// interrupt signal is not sent while the terminal is in raw mode.
+func interruptDaemon() {
+	if process, err := os.FindProcess(os.Getpid()); err == nil && process.Signal(os.Interrupt) == nil {
+		return
+	}
+
+	os.Exit(1)
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+)
+
+// socketOwner describes the user and group that a UNIX socket is owned by.
+// A value of -1 leaves the user or group unchanged.
+type socketOwner struct {
+	uid, gid int
+}
+
+// parseSocketMode parses the permissions of a UNIX socket, in octal.
+func parseSocketMode(mode string) (os.FileMode, error) {
+	perm, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || perm > 0o777 {
+		return 0, fmt.Errorf("Invalid socket mode '%s', must be an octal value like '0660'", mode)
+	}
+
+	return os.FileMode(perm), nil
+}
+
+// parseSocketOwner parses the owner of a UNIX socket, in the form 'user', 'user:group' or ':group',
+// where the user and group are either names or numeric IDs.
+func parseSocketOwner(owner string) (socketOwner, error) {
+	o := socketOwner{uid: -1, gid: -1}
+	if owner == "" {
+		return o, nil
+	}
+
+	name, group, _ := strings.Cut(owner, ":")
+	if name != "" {
+		uid, err := strconv.Atoi(name)
+		if err != nil {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return o, fmt.Errorf("Cannot find the socket owner '%s': %w", name, err)
+			}
+
+			if uid, err = strconv.Atoi(u.Uid); err != nil {
+				return o, fmt.Errorf("Socket owner '%s' does not have a numeric user ID", name)
+			}
+		}
+
+		o.uid = uid
+	}
+
+	if group != "" {
+		gid, err := strconv.Atoi(group)
+		if err != nil {
+			g, err := user.LookupGroup(group)
+			if err != nil {
+				return o, fmt.Errorf("Cannot find the socket group '%s': %w", group, err)
+			}
+
+			if gid, err = strconv.Atoi(g.Gid); err != nil {
+				return o, fmt.Errorf("Socket group '%s' does not have a numeric group ID", group)
+			}
+		}
+
+		o.gid = gid
+	}
+
+	return o, nil
+}
@@ -0,0 +1,29 @@
+//go:build !unix
+
+package app
+
+import (
+	"errors"
+	"net"
+	"os"
+)
+
+// listenUnix listens on the UNIX socket at path. Setting the owner of the socket
+// is not supported on this platform.
+func listenUnix(path string, mode os.FileMode, owner socketOwner) (net.Listener, error) {
+	if owner.uid != -1 || owner.gid != -1 {
+		return nil, errors.New("Setting the socket owner is not supported on this platform.")
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(path, mode); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
@@ -0,0 +1,36 @@
+//go:build unix
+
+package app
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+)
+
+// listenUnix listens on the UNIX socket at path, and sets its permissions and owner.
+// The socket is created with no permissions for other users, so that it cannot be
+// connected to before its permissions are set.
+func listenUnix(path string, mode os.FileMode, owner socketOwner) (net.Listener, error) {
+	umask := syscall.Umask(0o177)
+	listener, err := net.Listen("unix", path)
+	syscall.Umask(umask)
+	if err != nil {
+		return nil, err
+	}
+
+	if owner.uid != -1 || owner.gid != -1 {
+		if err := os.Chown(path, owner.uid, owner.gid); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("Cannot set the socket owner: %w", err)
+		}
+	}
+
+	if err := os.Chmod(path, mode); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("Cannot set the socket mode: %w", err)
+	}
+
+	return listener, nil
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+)
+
+// newTLSConfig returns the TLS configuration of the TCP listener, or nil if TLS is not enabled.
+// If a client CA bundle is set, client certificates are verified against it.
+func newTLSConfig(cliCtx *cli.Context) (*tls.Config, error) {
+	certFile, keyFile := cliCtx.String("tls-cert"), cliCtx.String("tls-key")
+	if certFile == "" && keyFile == "" {
+		if cliCtx.IsSet("tls-client-ca") {
+			return nil, errors.New("The '--tls-client-ca' option requires the '--tls-cert' and '--tls-key' options.")
+		}
+
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("Both the '--tls-cert' and '--tls-key' options must be specified.")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot load the TLS certificate: %w", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if caFile := cliCtx.String("tls-client-ca"); caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot read the client CA bundle: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("No certificates found in the client CA bundle '%s'", caFile)
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		if cliCtx.Bool("tls-require-client-cert") {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	} else if cliCtx.Bool("tls-require-client-cert") {
+		return nil, errors.New("The '--tls-require-client-cert' option requires the '--tls-client-ca' option.")
+	}
+
+	return config, nil
+}
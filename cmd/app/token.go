@@ -0,0 +1,106 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bluetuith-org/daemon/endpoints"
+	"github.com/pterm/pterm"
+	"github.com/urfave/cli/v2"
+)
+
+func cmdTokenCreate(cliCtx *cli.Context) error {
+	tokens, err := openTokenStore(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	secret, token, err := tokens.Create(cliCtx.String("name"), cliCtx.StringSlice("scope"))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cliCtx.App.ErrWriter, "Created token '%s' with the scopes: %s.\nThe token is only shown once:\n", token.ID, strings.Join(token.Scopes, ", "))
+	fmt.Fprintln(cliCtx.App.Writer, secret)
+
+	return nil
+}
+
+func cmdTokenList(cliCtx *cli.Context) error {
+	tokens, err := openTokenStore(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	list, err := tokens.Tokens()
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		printInfo("No tokens have been created.")
+		return nil
+	}
+
+	data := pterm.TableData{{"ID", "Name", "Scopes", "Created"}}
+	for _, token := range list {
+		data = append(data, []string{
+			token.ID, token.Name, strings.Join(token.Scopes, ", "), token.CreatedAt.Format(time.DateTime),
+		})
+	}
+
+	return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
+
+func cmdTokenRevoke(cliCtx *cli.Context) error {
+	id := cliCtx.Args().First()
+	if id == "" {
+		return errors.New("The ID of the token to revoke must be specified.")
+	}
+
+	tokens, err := openTokenStore(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	if err := tokens.Revoke(id); err != nil {
+		return err
+	}
+
+	printInfo("Revoked token '%s'.", id)
+
+	return nil
+}
+
+func openTokenStore(cliCtx *cli.Context) (*endpoints.TokenStore, error) {
+	path, err := tokenFile(cliCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoints.OpenTokenStore(path)
+}
+
+// tokenFile returns the path of the token file, which is in the user's
+// configuration directory unless the 'tokens' option is set.
+func tokenFile(cliCtx *cli.Context) (string, error) {
+	return configFile(cliCtx, "tokens", "tokens.json")
+}
+
+// configFile returns the path set by the option, or the path of the file
+// with the provided name in the user's configuration directory.
+func configFile(cliCtx *cli.Context, option, name string) (string, error) {
+	if path := cliCtx.String(option); path != "" {
+		return path, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("Cannot determine the path of '%s': %w", name, err)
+	}
+
+	return filepath.Join(configDir, "bluerestd", name), nil
+}
@@ -0,0 +1,137 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// accessControl authorizes API calls. Calls from UNIX socket clients are authorized
+// using their peer credentials if a peer policy is set, calls from TLS clients with a
+// verified certificate are authorized using the certificate policy if it is set,
+// and all other calls are authorized using the API tokens, if any.
+type accessControl struct {
+	tokens *TokenStore
+	peers  *PeerPolicy
+	certs  *CertPolicy
+}
+
+type tlsConnKey struct{}
+
+func newAccessControl(tokens *TokenStore, peers *PeerPolicy, certs *CertPolicy) *accessControl {
+	return &accessControl{tokens: tokens, peers: peers, certs: certs}
+}
+
+// enabled reports whether any calls need to be authorized.
+func (a *accessControl) enabled() bool {
+	return a.tokens != nil || a.peers != nil || a.certs != nil
+}
+
+// ConnContext adds the peer credentials of UNIX socket connections, and the
+// TLS connection of TLS clients, to the connection's context.
+// It is meant to be used as the 'ConnContext' of an HTTP server.
+func ConnContext(ctx context.Context, conn net.Conn) context.Context {
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		return context.WithValue(ctx, tlsConnKey{}, tlsConn)
+	}
+
+	caller, ok := peerCredentials(conn)
+	if !ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// authorize checks whether the caller in the context, or the token sent in the 'Authorization'
+// header or in the query, has been granted the scope. If scope is empty, any authorized caller
+// is accepted. If the call is not authorized, the status and a description of the error is returned.
+func (a *accessControl) authorize(ctx context.Context, header, query, scope string) (int, string) {
+	if caller, ok := CallerFromContext(ctx); ok && a.peers != nil {
+		return a.peers.authorize(caller, scope)
+	}
+
+	if name, ok := ClientCertificateFromContext(ctx); ok && a.certs != nil {
+		return a.certs.authorize(name, scope)
+	}
+
+	if a.tokens != nil {
+		return a.tokens.authorize(header, query, scope)
+	}
+
+	return http.StatusOK, ""
+}
+
+// middleware authorizes each call to an operation, using the scope required by the operation.
+func (a *accessControl) middleware(api huma.API) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		scope, public := operationScope(api, ctx)
+		if public {
+			next(ctx)
+			return
+		}
+
+		if status, message := a.authorize(ctx.Context(), ctx.Header("Authorization"), ctx.Query(tokenQueryParam), scope); status != http.StatusOK {
+			if status == http.StatusUnauthorized {
+				ctx.SetHeader("WWW-Authenticate", `Bearer realm="bluerestd"`)
+			}
+
+			huma.WriteErr(api, ctx, status, message)
+			return
+		}
+
+		next(ctx)
+	}
+}
+
+// handler wraps a handler that is not registered as an operation,
+// so that each request is authorized with the provided scope.
+func (a *accessControl) handler(scope string, next http.Handler) http.Handler {
+	if !a.enabled() {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status, message := a.authorize(r.Context(), r.Header.Get("Authorization"), r.URL.Query().Get(tokenQueryParam), scope); status != http.StatusOK {
+			if status == http.StatusUnauthorized {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="bluerestd"`)
+			}
+
+			http.Error(w, message, status)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// parseScopeRule parses an allowlist rule of the form 'scope=principal,...', where scope
+// is one of the token scopes, or '*' for all scopes.
+func parseScopeRule(rule string) ([]string, []string, error) {
+	scope, list, ok := strings.Cut(rule, "=")
+	if !ok || strings.TrimSpace(list) == "" {
+		return nil, nil, fmt.Errorf("Invalid allowlist rule '%s', must be of the form 'scope=name,...'", rule)
+	}
+
+	scopes := []string{scope}
+	if scope == "*" {
+		scopes = TokenScopes
+	} else if !slices.Contains(TokenScopes, scope) {
+		return nil, nil, fmt.Errorf("Invalid scope '%s' in allowlist rule, must be one of: *, %s", scope, strings.Join(TokenScopes, ", "))
+	}
+
+	var principals []string
+	for _, principal := range strings.Split(list, ",") {
+		if principal = strings.TrimSpace(principal); principal != "" {
+			principals = append(principals, principal)
+		}
+	}
+
+	return scopes, principals, nil
+}
@@ -26,6 +26,7 @@ func devicesEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/adapter/{address}/devices",
 		Summary:     "Devices",
 		Description: "This endpoint fetches the devices associated with an adapter.",
+		Security:    tokenScopes(ScopeRead),
 		Tags:        []string{"Adapter"},
 	}, func(_ context.Context, input *struct {
 		AddressInput
@@ -49,6 +50,7 @@ func adapterPropertiesEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/adapter/{address}/properties",
 		Summary:     "Properties",
 		Description: "This endpoint fetches the properties of an adapter.",
+		Security:    tokenScopes(ScopeRead),
 		Tags:        []string{"Adapter"},
 	}, func(_ context.Context, input *struct {
 		AddressInput
@@ -87,8 +89,10 @@ func statesEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/adapter/{address}/states",
 		Summary:     "States",
 		Description: "This endpoint, when called by itself, fetches the different states (powered, pairable, discoverable and device discovery) of an adapter. Use the **query parameters** to `enable` or `disable` each state. Note that when **discovery** is **enabled**, all discovered devices will be published to the `/event` stream, with the ***event-name*** as *'device'*, and with ***event-action*** as *'added'*.",
+		Security:    tokenScopes(ScopeControl),
 		Tags:        []string{"Adapter"},
-	}, func(_ context.Context, input *struct {
+		Metadata:    map[string]any{operationMutating: operationMutatingQuery},
+	}, func(ctx context.Context, input *struct {
 		AdapterStatesInput
 		AddressInput
 	}) (*AdapterStatesOutput, error) {
@@ -96,12 +100,14 @@ func statesEndpoint(api huma.API, session bluetooth.Session) {
 		adapterCall := session.Adapter(input.Address)
 
 		inputs := []struct {
+			Name              string
 			InputToCheck      string
 			EnableFunc        func() error
 			DisableFunc       func() error
 			SetStatesProperty func(string)
 		}{
 			{
+				Name:         "adapter.Discovery",
 				InputToCheck: input.Discovery,
 				EnableFunc:   adapterCall.StartDiscovery,
 				DisableFunc:  adapterCall.StopDiscovery,
@@ -110,6 +116,7 @@ func statesEndpoint(api huma.API, session bluetooth.Session) {
 				},
 			},
 			{
+				Name:         "adapter.SetDiscoverableState",
 				InputToCheck: input.Discoverable,
 				EnableFunc: func() error {
 					return adapterCall.SetDiscoverableState(true)
@@ -122,6 +129,7 @@ func statesEndpoint(api huma.API, session bluetooth.Session) {
 				},
 			},
 			{
+				Name:         "adapter.SetPairableState",
 				InputToCheck: input.Pairable,
 				EnableFunc: func() error {
 					return adapterCall.SetPairableState(true)
@@ -134,6 +142,7 @@ func statesEndpoint(api huma.API, session bluetooth.Session) {
 				},
 			},
 			{
+				Name:         "adapter.SetPoweredState",
 				InputToCheck: input.Powered,
 				EnableFunc: func() error {
 					return adapterCall.SetPoweredState(true)
@@ -156,10 +165,10 @@ func statesEndpoint(api huma.API, session bluetooth.Session) {
 
 			switch in.InputToCheck {
 			case "enable":
-				err = in.EnableFunc()
+				err = sessionCall(ctx, in.Name, input.Address, in.EnableFunc)
 				state = "enabled"
 			case "disable":
-				err = in.DisableFunc()
+				err = sessionCall(ctx, in.Name, input.Address, in.DisableFunc)
 				state = "disabled"
 			case "":
 				emptyInputs++
@@ -1,9 +1,16 @@
 package endpoints
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/bluetuith-org/api-native/api/bluetooth"
 	"github.com/bluetuith-org/api-native/api/eventbus"
@@ -12,17 +19,29 @@ import (
 	"github.com/puzpuzpuz/xsync/v3"
 )
 
-type authRequestEvent struct {
+type AuthRequestEvent struct {
 	ID            int64  `json:"auth_id,omitempty" doc:"The ID of the authorization request."`
-	ReplyRequired bool   "json:\"reply_required,omitempty\" doc:\"If this parameter is set to 'true', use the `/auth/{auth_id}/{reply}` endpoint to respond to this request, otherwise ignore.\""
+	Action        string "json:\"action,omitempty\" enum:\"requested,answered,expired,cancelled\" doc:\"The state of the authorization request. An event is published when the request is created (`requested`), and if it requires a reply, when it is answered, when it times out (`expired`), or when it is cancelled by the device.\""
+	ReplyRequired bool   "json:\"reply_required,omitempty\" doc:\"If this parameter is set to 'true', use the `/auth/{auth_id}/{reply}` endpoint to respond to this request, otherwise ignore. The `request-pincode` and `request-passkey` requests must be answered with a value, using a POST request on the `/auth/{auth_id}` endpoint.\""
 	AuthType      string `json:"auth_type,omitempty" enum:"pairing,transfer" doc:"The type of the authorization request."`
+	Reply         string "json:\"reply,omitempty\" enum:\"yes,no\" doc:\"The reply to the authorization request, if it has been `answered`.\""
+	Reason        string "json:\"reason,omitempty\" doc:\"The reason of the reply, if the reply is `no`.\""
 
 	PairingParams  *authPairingEvent  "json:\"pairing_params,omitempty\" doc:\"The parameters of the `pairing` authorization request.\""
 	TransferParams *authTransferEvent "json:\"transfer_params,omitempty\" doc:\"The parameters of the `transfer` authorization request.\""
+
+	AutoReply *authAutoReply `json:"auto_reply,omitempty" doc:"The authorization rule that automatically replied to the request, if any."`
+}
+
+type authAutoReply struct {
+	RuleID   string `json:"rule_id" doc:"The ID of the rule."`
+	RuleName string `json:"rule_name,omitempty" doc:"The name of the rule."`
+	Reply    string `json:"reply" enum:"yes,no" doc:"The reply of the rule."`
+	Reason   string `json:"reason,omitempty" doc:"The reason of the reply, if the reply is 'no'."`
 }
 
 type authPairingEvent struct {
-	PairingType string `json:"pairing_type,omitempty" enum:"display-pincode,display-passkey,confirm-passkey,authorize-pairing,authorize-service" doc:"The type of the pairing authorization request."`
+	PairingType string `json:"pairing_type,omitempty" enum:"display-pincode,display-passkey,confirm-passkey,authorize-pairing,authorize-service,request-pincode,request-passkey" doc:"The type of the pairing authorization request."`
 
 	Address     bluetooth.MacAddress `json:"address,omitempty" doc:"The address of the device."`
 	Pincode     string               `json:"pincode,omitempty" doc:"The provided pincode value."`
@@ -39,51 +58,282 @@ type authTransferEvent struct {
 type authEventReply struct {
 	reply  bool
 	reason string
+
+	// pincode and passkey are the values entered for the 'request-pincode'
+	// and 'request-passkey' requests respectively.
+	pincode string
+	passkey *uint32
+}
+
+// AuthReply is the reply of an authorization agent to an authorization request.
+type AuthReply struct {
+	// Accept accepts the request, otherwise it is rejected with the reason.
+	Accept bool
+	Reason string
+
+	// Pincode and Passkey are the values entered for the 'request-pincode'
+	// and 'request-passkey' requests respectively.
+	Pincode string
+	Passkey *uint32
+}
+
+// AuthAgent answers authorization requests alongside the API clients.
+type AuthAgent interface {
+	// Answer is called for each authorization request that requires a reply. The reply is sent
+	// using the reply function, which returns an error if the reply is not valid for the request,
+	// and the context is done once the request has been answered by anyone, or has timed out
+	// or been cancelled.
+	Answer(ctx context.Context, data AuthRequestEvent, reply func(AuthReply) error)
+}
+
+// authReplyBody is the reply to an authorization request, which is sent in a POST request.
+type authReplyBody struct {
+	Reply   string  `json:"reply,omitempty" enum:"yes,no" default:"yes" doc:"The reply to the authorization request."`
+	Reason  string  "json:\"reason,omitempty\" doc:\"An optional user-specified reason if the reply is `no`.\""
+	Pincode string  "json:\"pincode,omitempty\" pattern:\"^[0-9]{1,16}$\" doc:\"The PIN code to pair with, for `request-pincode` requests.\""
+	Passkey *uint32 "json:\"passkey,omitempty\" maximum:\"999999\" doc:\"The 6-digit passkey to pair with, for `request-passkey` requests.\""
+}
+
+// authRequest is an authorization request that is waiting for a reply.
+type authRequest struct {
+	data      AuthRequestEvent
+	createdAt time.Time
+	deadline  time.Time
+	reply     chan authEventReply
+}
+
+// authPendingRequest describes an authorization request that is waiting for a reply.
+type authPendingRequest struct {
+	Request   AuthRequestEvent `json:"request" doc:"The authorization request, as published in the 'auth' event."`
+	CreatedAt time.Time        `json:"created_at" doc:"The time at which the request was created."`
+	ExpiresAt *time.Time       `json:"expires_at,omitempty" doc:"The time at which the request times out."`
+	Remaining float64          `json:"remaining,omitempty" doc:"The remaining time until the request times out, in seconds."`
+}
+
+// authErrorEventData is published as an 'error' event, if an authorization request is
+// rejected since no authorization agent is available to answer it.
+type authErrorEventData struct {
+	AuthID   int64  `json:"auth_id" doc:"The ID of the authorization request."`
+	AuthType string `json:"auth_type" doc:"The type of the authorization request."`
+	Address  string `json:"address,omitempty" doc:"The address of the device."`
+	Error    string `json:"error" doc:"The reason why the request was rejected."`
 }
 
 type authEventID uint
 
-const authEvent = authEventID(100)
+type authErrorEventID uint
+
+// The policies for authorization requests that are created while no authorization agent
+// is available: the requests are either rejected or accepted immediately, or they wait
+// for a reply until they time out. Requests for a PIN code or passkey are never accepted,
+// since no value can be provided for them.
+const (
+	AuthNoAgentReject = "reject"
+	AuthNoAgentAccept = "accept"
+	AuthNoAgentWait   = "wait"
+)
+
+// AuthNoAgentPolicies lists all policies for requests that are created while no authorization agent is available.
+var AuthNoAgentPolicies = []string{AuthNoAgentReject, AuthNoAgentAccept, AuthNoAgentWait}
+
+// authNoAgentReason is the reason of the reply to requests that are rejected since no authorization agent is available.
+const authNoAgentReason = "No authorization agent is available to answer the request."
 
-var requests = xsync.NewMapOf[int64, chan authEventReply]()
+// maxPasskey is the largest passkey, since passkeys consist of 6 digits.
+const maxPasskey = 999999
+
+const (
+	authEvent      = authEventID(100)
+	authErrorEvent = authErrorEventID(102)
+)
+
+// requests holds the authorization requests that are waiting for a reply. A request is removed
+// when it is answered, or when it times out or is cancelled, whichever happens first.
+var requests = xsync.NewMapOf[int64, *authRequest]()
+
+// authAgentClients is the number of API clients that are registered as authorization agents,
+// that is, the event subscriptions which answer the authorization requests.
+var authAgentClients atomic.Int64
 
 func authEndpoint(api huma.API) {
+	type AuthRequestsOutput struct {
+		Body []authPendingRequest
+	}
+
+	type AuthRequestOutput struct {
+		Body authPendingRequest
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "auth-requests",
+		Method:      http.MethodGet,
+		Path:        "/auth",
+		Summary:     "Pending Authorizations",
+		Description: "This endpoint fetches all authorization requests that are waiting for a reply, along with the remaining time until each request times out.",
+		Security:    tokenScopes(ScopeRead),
+	}, func(_ context.Context, _ *struct{}) (*AuthRequestsOutput, error) {
+		pending := []authPendingRequest{}
+		requests.Range(func(_ int64, request *authRequest) bool {
+			pending = append(pending, request.pending())
+			return true
+		})
+
+		slices.SortFunc(pending, func(a, b authPendingRequest) int {
+			return cmp.Compare(a.Request.ID, b.Request.ID)
+		})
+
+		return &AuthRequestsOutput{pending}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "auth-request",
+		Method:      http.MethodGet,
+		Path:        "/auth/{auth_id}",
+		Summary:     "Pending Authorization",
+		Description: "This endpoint fetches an authorization request that is waiting for a reply, along with the remaining time until it times out.",
+		Security:    tokenScopes(ScopeRead),
+	}, func(_ context.Context, input *struct {
+		ID int64 "path:\"auth_id\" doc:\"The authorization ID provided by the `auth` event.\""
+	}) (*AuthRequestOutput, error) {
+		request, ok := requests.Load(input.ID)
+		if !ok {
+			return nil, huma.Error404NotFound("Authorization ID not found.")
+		}
+
+		return &AuthRequestOutput{request.pending()}, nil
+	})
+
 	huma.Register(api, huma.Operation{
 		OperationID: "auth",
 		Method:      http.MethodGet,
 		Path:        "/auth/{auth_id}/{reply}",
 		Summary:     "Authorization",
 		Description: "This endpoint enables responses to authorization requests, like device pairing or receiving file transfers.",
+		Security:    tokenScopes(ScopeAuthReply),
+		Metadata:    map[string]any{operationMutating: true},
 	}, func(_ context.Context, input *struct {
 		ID     int64  "path:\"auth_id\" doc:\"The authorization ID provided by the `auth` event.\""
 		Reply  string `path:"reply" json:"reply,omitempty" enum:"yes,no" doc:"The reply to an authorization request."`
 		Reason string "query:\"reason\" json:\"reason,omitempty\" doc:\"An optional user-specified reason if the reply is `no`.\""
 	}) (*struct{}, error) {
 		if input.ID <= 0 {
-			return nil, errors.New("Invalid authorization ID.")
+			return nil, huma.Error422UnprocessableEntity("Invalid authorization ID.")
 		}
 
-		ch, ok := requests.LoadAndDelete(input.ID)
-		if !ok {
-			return nil, errors.New("Authorization ID not found.")
-		}
+		return nil, answerAuthRequest(input.ID, authEventReply{reply: input.Reply == "yes", reason: input.Reason})
+	})
 
-		ch <- authEventReply{input.Reply == "yes", input.Reason}
+	huma.Register(api, huma.Operation{
+		OperationID: "auth-reply",
+		Method:      http.MethodPost,
+		Path:        "/auth/{auth_id}",
+		Summary:     "Authorization Reply",
+		Description: "This endpoint enables responses to authorization requests, like the `/auth/{auth_id}/{reply}` endpoint. The `request-pincode` and `request-passkey` requests must be accepted using this endpoint, with the PIN code or passkey that is entered on the device.",
+		Security:    tokenScopes(ScopeAuthReply),
+		Metadata:    map[string]any{operationMutating: true},
+	}, func(_ context.Context, input *struct {
+		ID   int64 "path:\"auth_id\" doc:\"The authorization ID provided by the `auth` event.\""
+		Body authReplyBody
+	}) (*struct{}, error) {
+		if input.ID <= 0 {
+			return nil, huma.Error422UnprocessableEntity("Invalid authorization ID.")
+		}
 
-		return nil, nil
+		return nil, answerAuthRequest(input.ID, authEventReply{
+			reply:   input.Body.Reply != "no",
+			reason:  input.Body.Reason,
+			pincode: input.Body.Pincode,
+			passkey: input.Body.Passkey,
+		})
 	})
 }
 
+// answerAuthRequest sends the reply to the pending authorization request, if the reply is valid for it.
+func answerAuthRequest(id int64, reply authEventReply) error {
+	request, ok := requests.Load(id)
+	if !ok {
+		return huma.Error404NotFound("Authorization ID not found.")
+	}
+
+	pairingType := ""
+	if request.data.PairingParams != nil {
+		pairingType = request.data.PairingParams.PairingType
+	}
+
+	switch {
+	case pairingType == "request-pincode" && reply.reply && reply.pincode == "":
+		return huma.Error422UnprocessableEntity("A PIN code is required to accept this request.")
+
+	case pairingType == "request-passkey" && reply.reply && reply.passkey == nil:
+		return huma.Error422UnprocessableEntity("A passkey is required to accept this request.")
+
+	case pairingType != "request-pincode" && reply.pincode != "",
+		pairingType != "request-passkey" && reply.passkey != nil:
+		return huma.Error422UnprocessableEntity("A value can only be sent for 'request-pincode' and 'request-passkey' requests.")
+
+	case reply.pincode != "" && !validPincode(reply.pincode):
+		return huma.Error422UnprocessableEntity("The PIN code must consist of 1 to 16 digits.")
+
+	case reply.passkey != nil && *reply.passkey > maxPasskey:
+		return huma.Error422UnprocessableEntity("The passkey must be a number between 0 and 999999.")
+	}
+
+	if _, ok := requests.LoadAndDelete(id); !ok {
+		return huma.Error404NotFound("Authorization ID not found.")
+	}
+
+	request.reply <- reply
+
+	return nil
+}
+
+// validPincode reports whether the PIN code consists of 1 to 16 digits.
+func validPincode(pincode string) bool {
+	if len(pincode) == 0 || len(pincode) > 16 {
+		return false
+	}
+
+	for _, r := range pincode {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
 type authorizer struct {
-	id *xsync.Counter
+	id      atomic.Int64
+	rules   *AuthRules
+	agents  []AuthAgent
+	noAgent string
+}
+
+// AuthorizerConfig describes how authorization requests are answered,
+// in addition to the API clients that receive the 'auth' events.
+type AuthorizerConfig struct {
+	// Rules, if set, automatically reply to the requests that they match.
+	Rules *AuthRules
+
+	// Agents answer each request that requires a reply, such as a hook
+	// that runs a command, or a prompt on the daemon's terminal.
+	Agents []AuthAgent
+
+	// NoAgent is the policy for requests that are created while neither an API client is
+	// registered as an authorization agent, nor any agents are set. It is one of
+	// AuthNoAgentPolicies, and requests are rejected if it is empty.
+	NoAgent string
 }
 
-func NewAuthorizer() *authorizer {
-	return &authorizer{id: xsync.NewCounter()}
+// NewAuthorizer returns the authorizer of the Bluetooth session, which publishes authorization
+// requests as 'auth' events. If rules are provided, the first rule that matches a request replies to it,
+// otherwise the request is answered by whichever replies first, the API clients or the agents.
+func NewAuthorizer(config AuthorizerConfig) *authorizer {
+	return &authorizer{rules: config.Rules, agents: config.Agents, noAgent: cmp.Or(config.NoAgent, AuthNoAgentReject)}
 }
 
 func (a *authorizer) AuthorizeTransfer(timeout bluetooth.AuthTimeout, path string, props bluetooth.FileTransferData) error {
-	return a.sendAndWait(timeout, authRequestEvent{
+	return a.sendAndWait(timeout, AuthRequestEvent{
 		AuthType:      "transfer",
 		ReplyRequired: true,
 		TransferParams: &authTransferEvent{
@@ -94,7 +344,7 @@ func (a *authorizer) AuthorizeTransfer(timeout bluetooth.AuthTimeout, path strin
 }
 
 func (a *authorizer) DisplayPinCode(timeout bluetooth.AuthTimeout, address bluetooth.MacAddress, pincode string) error {
-	a.send(authRequestEvent{
+	a.send(AuthRequestEvent{
 		AuthType:      "pairing",
 		ReplyRequired: false,
 		PairingParams: &authPairingEvent{
@@ -108,7 +358,7 @@ func (a *authorizer) DisplayPinCode(timeout bluetooth.AuthTimeout, address bluet
 }
 
 func (a *authorizer) DisplayPasskey(timeout bluetooth.AuthTimeout, address bluetooth.MacAddress, passkey uint32, entered uint16) error {
-	a.send(authRequestEvent{
+	a.send(AuthRequestEvent{
 		AuthType:      "pairing",
 		ReplyRequired: false,
 		PairingParams: &authPairingEvent{
@@ -123,7 +373,7 @@ func (a *authorizer) DisplayPasskey(timeout bluetooth.AuthTimeout, address bluet
 }
 
 func (a *authorizer) ConfirmPasskey(timeout bluetooth.AuthTimeout, address bluetooth.MacAddress, passkey uint32) error {
-	return a.sendAndWait(timeout, authRequestEvent{
+	return a.sendAndWait(timeout, AuthRequestEvent{
 		AuthType:      "pairing",
 		ReplyRequired: true,
 		PairingParams: &authPairingEvent{
@@ -134,8 +384,39 @@ func (a *authorizer) ConfirmPasskey(timeout bluetooth.AuthTimeout, address bluet
 	})
 }
 
+// RequestPinCode requests the PIN code that is entered on the device, for legacy pairing.
+func (a *authorizer) RequestPinCode(timeout bluetooth.AuthTimeout, address bluetooth.MacAddress) (string, error) {
+	reply := a.request(timeout, AuthRequestEvent{
+		AuthType:      "pairing",
+		ReplyRequired: true,
+		PairingParams: &authPairingEvent{
+			PairingType: "request-pincode",
+			Address:     address,
+		},
+	})
+
+	return reply.pincode, reply.err()
+}
+
+// RequestPasskey requests the passkey that is entered on the device.
+func (a *authorizer) RequestPasskey(timeout bluetooth.AuthTimeout, address bluetooth.MacAddress) (uint32, error) {
+	reply := a.request(timeout, AuthRequestEvent{
+		AuthType:      "pairing",
+		ReplyRequired: true,
+		PairingParams: &authPairingEvent{
+			PairingType: "request-passkey",
+			Address:     address,
+		},
+	})
+	if err := reply.err(); err != nil {
+		return 0, err
+	}
+
+	return *reply.passkey, nil
+}
+
 func (a *authorizer) AuthorizePairing(timeout bluetooth.AuthTimeout, address bluetooth.MacAddress) error {
-	return a.sendAndWait(timeout, authRequestEvent{
+	return a.sendAndWait(timeout, AuthRequestEvent{
 		AuthType:      "pairing",
 		ReplyRequired: true,
 		PairingParams: &authPairingEvent{
@@ -146,7 +427,7 @@ func (a *authorizer) AuthorizePairing(timeout bluetooth.AuthTimeout, address blu
 }
 
 func (a *authorizer) AuthorizeService(timeout bluetooth.AuthTimeout, address bluetooth.MacAddress, uuid uuid.UUID) error {
-	return a.sendAndWait(timeout, authRequestEvent{
+	return a.sendAndWait(timeout, AuthRequestEvent{
 		AuthType:      "pairing",
 		ReplyRequired: true,
 		PairingParams: &authPairingEvent{
@@ -157,32 +438,193 @@ func (a *authorizer) AuthorizeService(timeout bluetooth.AuthTimeout, address blu
 	})
 }
 
-func (a *authorizer) send(data authRequestEvent) int64 {
-	a.id.Inc()
-	data.ID = a.id.Value()
+func (a *authorizer) send(data AuthRequestEvent) int64 {
+	data.ID = a.id.Add(1)
+	data.Action = "requested"
 
 	eventbus.Publish(authEvent, data)
+	logAuthRequest(data)
 
 	return data.ID
 }
 
-func (a *authorizer) sendAndWait(timeout bluetooth.AuthTimeout, data authRequestEvent) error {
+func (a *authorizer) sendAndWait(timeout bluetooth.AuthTimeout, data AuthRequestEvent) error {
+	return a.request(timeout, data).err()
+}
+
+// request publishes the authorization request, and waits until it is answered,
+// times out or is cancelled. The reply is only accepted if it is answered.
+func (a *authorizer) request(timeout bluetooth.AuthTimeout, data AuthRequestEvent) authEventReply {
 	var reply authEventReply
 
-	ch := make(chan authEventReply, 1)
-	requests.Store(a.send(data), ch)
+	started := time.Now()
+	data.ID = a.id.Add(1)
+
+	if rule, ok := a.rules.match(data); ok {
+		data.AutoReply = &authAutoReply{
+			RuleID:   rule.ID,
+			RuleName: rule.Name,
+			Reply:    rule.Reply,
+			Reason:   rule.Reason,
+		}
+
+		return a.answerImmediately(data, rule.reply(), started)
+	}
+
+	if !a.agentAvailable() {
+		policy := a.noAgent
+		if policy == AuthNoAgentAccept && authRequestsValue(data) {
+			// Requests for a PIN code or passkey cannot be accepted without a value, so they are rejected.
+			policy = AuthNoAgentReject
+		}
+
+		switch policy {
+		case AuthNoAgentAccept:
+			return a.answerImmediately(data, authEventReply{reply: true}, started)
+
+		case AuthNoAgentReject:
+			reply := a.answerImmediately(data, authEventReply{reason: authNoAgentReason}, started)
+			eventbus.Publish(authErrorEvent, authErrorEventData{
+				AuthID:   data.ID,
+				AuthType: data.AuthType,
+				Address:  authRequestAddress(data),
+				Error:    authNoAgentReason,
+			})
+
+			return reply
+		}
+
+		slog.Warn("No authorization agent is available, waiting for a reply until the request times out", "auth_id", data.ID)
+	}
+
+	// The request is stored before it is published, so that it can be answered immediately.
+	data.Action = "requested"
+	request := &authRequest{
+		data:      data,
+		createdAt: started,
+		reply:     make(chan authEventReply, 1),
+	}
+	request.deadline, _ = timeout.Deadline()
+	requests.Store(data.ID, request)
+
+	eventbus.Publish(authEvent, data)
+	logAuthRequest(data)
+	endSpan := traceAuthRequest(data)
+
+	// The agents are stopped once the request is no longer pending.
+	agentsCtx, stopAgents := context.WithCancel(timeout)
+	agentReply := func(reply AuthReply) error {
+		return answerAuthRequest(data.ID, authEventReply{
+			reply:   reply.Accept,
+			reason:  reply.Reason,
+			pincode: reply.Pincode,
+			passkey: reply.Passkey,
+		})
+	}
+	for _, agent := range a.agents {
+		go agent.Answer(agentsCtx, data, agentReply)
+	}
+
 	select {
 	case <-timeout.Done():
-	case reply = <-ch:
+		// If the request has already been removed, it was answered before it timed out.
+		if _, ok := requests.LoadAndDelete(data.ID); !ok {
+			reply = <-request.reply
+			data.Action = "answered"
+			break
+		}
+
+		data.Action = "cancelled"
+		if errors.Is(timeout.Err(), context.DeadlineExceeded) {
+			data.Action = "expired"
+		}
+
+	case reply = <-request.reply:
+		data.Action = "answered"
 	}
 
+	stopAgents()
+
+	data.ReplyRequired = false
+	if data.Action == "answered" {
+		data.Reply, data.Reason = "no", reply.reason
+		if reply.reply {
+			data.Reply = "yes"
+		}
+	}
+
+	eventbus.Publish(authEvent, data)
+	endSpan(data.Action, reply)
+	logAuthReply(data, started)
+
+	return reply
+}
+
+// answerImmediately publishes the request, which is answered with the reply without waiting.
+func (a *authorizer) answerImmediately(data AuthRequestEvent, reply authEventReply, started time.Time) authEventReply {
+	data.ReplyRequired = false
+	data.Action, data.Reply, data.Reason = "answered", "no", reply.reason
 	if reply.reply {
-		return nil
+		data.Reply = "yes"
 	}
 
+	eventbus.Publish(authEvent, data)
+	logAuthRequest(data)
+	traceAuthRequest(data)(data.Action, reply)
+	logAuthReply(data, started)
+
 	return reply
 }
 
+// agentAvailable reports whether any API client or agent can answer authorization requests.
+func (a *authorizer) agentAvailable() bool {
+	return len(a.agents) > 0 || authAgentClients.Load() > 0
+}
+
+// registerAuthAgent registers an API client as an authorization agent,
+// until the returned function is called.
+func registerAuthAgent() func() {
+	authAgentClients.Add(1)
+
+	return sync.OnceFunc(func() {
+		authAgentClients.Add(-1)
+	})
+}
+
+// authRequestsValue reports whether the request is for a PIN code or passkey, which
+// is entered on the device and must be provided to accept the request.
+func authRequestsValue(data AuthRequestEvent) bool {
+	return data.PairingParams != nil && strings.HasPrefix(data.PairingParams.PairingType, "request-")
+}
+
+// authRequestAddress returns the address of the device that the request is associated with.
+func authRequestAddress(data AuthRequestEvent) string {
+	switch {
+	case data.PairingParams != nil:
+		return data.PairingParams.Address.String()
+
+	case data.TransferParams != nil:
+		return data.TransferParams.FileProperties.Address.String()
+	}
+
+	return ""
+}
+
+// pending describes the request, along with the remaining time until it times out.
+func (r *authRequest) pending() authPendingRequest {
+	pending := authPendingRequest{
+		Request:   r.data,
+		CreatedAt: r.createdAt,
+	}
+
+	if !r.deadline.IsZero() {
+		pending.ExpiresAt = &r.deadline
+		pending.Remaining = max(time.Until(r.deadline), 0).Seconds()
+	}
+
+	return pending
+}
+
 func (i authEventID) String() string {
 	return "auth"
 }
@@ -191,6 +633,23 @@ func (i authEventID) Value() uint {
 	return uint(i)
 }
 
+func (i authErrorEventID) String() string {
+	return "error"
+}
+
+func (i authErrorEventID) Value() uint {
+	return uint(i)
+}
+
+// err returns the reply as an error, if the request was not accepted.
+func (a authEventReply) err() error {
+	if a.reply {
+		return nil
+	}
+
+	return a
+}
+
 func (a authEventReply) Error() string {
 	if a.reply {
 		return ""
@@ -0,0 +1,176 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+)
+
+// testAuthAgent answers each request with the replies in order, until one of them is accepted.
+type testAuthAgent struct {
+	replies []AuthReply
+	errs    chan error
+}
+
+func (a *testAuthAgent) Answer(_ context.Context, _ AuthRequestEvent, reply func(AuthReply) error) {
+	for _, r := range a.replies {
+		err := reply(r)
+		a.errs <- err
+
+		if err == nil {
+			return
+		}
+	}
+}
+
+func testAuthTimeout(t *testing.T, timeout time.Duration) bluetooth.AuthTimeout {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	t.Cleanup(cancel)
+
+	return bluetooth.AuthTimeout{Context: ctx}
+}
+
+func TestRequestPasskeyNoAgent(t *testing.T) {
+	for _, policy := range AuthNoAgentPolicies {
+		t.Run(policy, func(t *testing.T) {
+			a := NewAuthorizer(AuthorizerConfig{NoAgent: policy})
+
+			started := time.Now()
+			passkey, err := a.RequestPasskey(testAuthTimeout(t, 200*time.Millisecond), bluetooth.MacAddress{})
+			if err == nil {
+				t.Fatalf("Request was accepted with passkey %d, want it to be rejected", passkey)
+			}
+
+			switch policy {
+			case AuthNoAgentReject, AuthNoAgentAccept:
+				if err.Error() != authNoAgentReason {
+					t.Errorf("Request was rejected with %q, want %q", err, authNoAgentReason)
+				}
+				if elapsed := time.Since(started); elapsed >= 200*time.Millisecond {
+					t.Errorf("Request was rejected after %v, want it to be rejected immediately", elapsed)
+				}
+
+			case AuthNoAgentWait:
+				if elapsed := time.Since(started); elapsed < 200*time.Millisecond {
+					t.Errorf("Request was rejected after %v, want it to wait until it times out", elapsed)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestPasskeyAgent(t *testing.T) {
+	const want = 123456
+
+	passkey, invalid := uint32(want), uint32(maxPasskey+1)
+	agent := &testAuthAgent{
+		replies: []AuthReply{
+			{Accept: true},
+			{Accept: true, Passkey: &invalid},
+			{Accept: true, Passkey: &passkey},
+		},
+		errs: make(chan error, 3),
+	}
+
+	a := NewAuthorizer(AuthorizerConfig{Agents: []AuthAgent{agent}})
+
+	got, err := a.RequestPasskey(testAuthTimeout(t, 5*time.Second), bluetooth.MacAddress{})
+	if err != nil {
+		t.Fatalf("Request was rejected: %v", err)
+	}
+	if got != want {
+		t.Errorf("Passkey is %d, want %d", got, want)
+	}
+
+	for i, reply := range agent.replies {
+		err := <-agent.errs
+		if valid := i == len(agent.replies)-1; valid != (err == nil) {
+			t.Errorf("Reply %d with passkey %v returned error %v", i+1, reply.Passkey, err)
+		}
+	}
+}
+
+func TestAuthorizePairingNoAgentAccept(t *testing.T) {
+	a := NewAuthorizer(AuthorizerConfig{NoAgent: AuthNoAgentAccept})
+
+	if err := a.AuthorizePairing(testAuthTimeout(t, time.Second), bluetooth.MacAddress{}); err != nil {
+		var reply authEventReply
+		if errors.As(err, &reply) {
+			t.Fatalf("Request was rejected with %q, want it to be accepted", reply.reason)
+		}
+
+		t.Fatal(err)
+	}
+}
+
+func TestAuthReplyPincode(t *testing.T) {
+	router := http.NewServeMux()
+	authEndpoint(humago.New(router, huma.DefaultConfig("Test", "1.0.0")))
+
+	// The request waits for a reply from the API clients, since one is registered as an agent.
+	defer registerAuthAgent()()
+
+	type result struct {
+		pincode string
+		err     error
+	}
+
+	results := make(chan result, 1)
+	go func() {
+		pincode, err := NewAuthorizer(AuthorizerConfig{}).RequestPinCode(testAuthTimeout(t, 5*time.Second), bluetooth.MacAddress{})
+		results <- result{pincode, err}
+	}()
+
+	var id int64
+	for id == 0 {
+		requests.Range(func(key int64, request *authRequest) bool {
+			if authRequestsValue(request.data) {
+				id = key
+			}
+
+			return id == 0
+		})
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "no PIN code", body: `{}`, want: http.StatusUnprocessableEntity},
+		{name: "invalid PIN code", body: `{"pincode": "12a4"}`, want: http.StatusUnprocessableEntity},
+		{name: "passkey", body: `{"passkey": 1234}`, want: http.StatusUnprocessableEntity},
+		{name: "PIN code", body: `{"pincode": "1234"}`, want: http.StatusNoContent},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/auth/%d", id), strings.NewReader(test.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			if resp.Code != test.want {
+				t.Errorf("Reply returned status %d, want %d: %s", resp.Code, test.want, resp.Body)
+			}
+		})
+	}
+
+	if r := <-results; r.err != nil || r.pincode != "1234" {
+		t.Errorf("Request returned PIN code %q and error %v, want '1234'", r.pincode, r.err)
+	}
+}
@@ -0,0 +1,175 @@
+package endpoints
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// authHookWaitDelay is the duration to wait for the output of a hook to be closed,
+// after the hook has exited or has been killed.
+const authHookWaitDelay = time.Second
+
+// AuthHook runs a command for each authorization request that requires a reply.
+//
+// The request is written to the standard input of the command as JSON, in the same format as the
+// 'auth' event. An exit status of 0 accepts the request, and an exit status of 1 rejects it, with
+// the first line of the standard output as the reason. To accept 'request-pincode' and 'request-passkey'
+// requests, the first line of the standard output must be the PIN code or passkey. Alternatively, the
+// command can print a JSON object with the 'reply', 'reason', 'pincode' and 'passkey' properties,
+// like the body of the 'POST /auth/{auth_id}' endpoint, where the reply defaults to the one of the exit
+// status. Any other exit status leaves the request to be answered by the API clients.
+//
+// The command is killed if the request is answered by an API client first, or if it times out.
+type AuthHook struct {
+	name string
+	args []string
+}
+
+// authHookReply is the reply that a hook prints as a JSON object.
+type authHookReply struct {
+	Reply   string  `json:"reply"`
+	Reason  string  `json:"reason"`
+	Pincode string  `json:"pincode"`
+	Passkey *uint32 `json:"passkey"`
+}
+
+// NewAuthHook returns a hook that runs the command. The command is split into
+// its arguments on whitespace, and it is not run using a shell.
+func NewAuthHook(command string) (*AuthHook, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, errors.New("The authorization hook command is empty.")
+	}
+
+	name, err := exec.LookPath(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("Cannot find the authorization hook command '%s': %w", args[0], err)
+	}
+
+	return &AuthHook{name: name, args: args[1:]}, nil
+}
+
+// Answer runs the command for the request, and replies with its exit status and output.
+func (h *AuthHook) Answer(ctx context.Context, data AuthRequestEvent, reply func(AuthReply) error) {
+	input, err := json.Marshal(data)
+	if err != nil {
+		slog.Error("Cannot encode the authorization request for the hook", "auth_id", data.ID, "error", err)
+		return
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, h.name, h.args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = authHookWaitDelay
+
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		slog.Debug("Authorization hook stopped, since the request is no longer pending", "auth_id", data.ID)
+		return
+	}
+
+	status := 0
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			slog.Error("Cannot run the authorization hook", "auth_id", data.ID, "error", err)
+			return
+		}
+
+		status = exitErr.ExitCode()
+	}
+
+	r, err := parseAuthHookReply(data, status, stdout.Bytes())
+	if err != nil {
+		slog.Warn("Authorization hook did not reply", "auth_id", data.ID, "status", status, "error", err, "stderr", hookOutput(stderr.Bytes()))
+		return
+	}
+
+	if err := reply(r); err != nil {
+		slog.Warn("Authorization hook reply was not accepted", "auth_id", data.ID, "error", err)
+	}
+}
+
+// parseAuthHookReply returns the reply of a hook to the request, from its exit status and its output.
+func parseAuthHookReply(data AuthRequestEvent, status int, output []byte) (AuthReply, error) {
+	var reply AuthReply
+
+	switch status {
+	case 0:
+		reply.Accept = true
+	case 1:
+	default:
+		return reply, fmt.Errorf("The hook exited with status %d", status)
+	}
+
+	if output = bytes.TrimSpace(output); bytes.HasPrefix(output, []byte("{")) {
+		var r authHookReply
+		if err := json.Unmarshal(output, &r); err != nil {
+			return reply, fmt.Errorf("Invalid JSON reply: %w", err)
+		}
+
+		switch r.Reply {
+		case "":
+		case "yes", "no":
+			reply.Accept = r.Reply == "yes"
+		default:
+			return reply, fmt.Errorf("Invalid reply '%s', must be one of: yes, no", r.Reply)
+		}
+
+		reply.Reason, reply.Pincode, reply.Passkey = r.Reason, r.Pincode, r.Passkey
+
+		return reply, nil
+	}
+
+	line, _, _ := bufio.NewReader(bytes.NewReader(output)).ReadLine()
+	value := strings.TrimSpace(string(line))
+
+	if !reply.Accept {
+		reply.Reason = value
+		return reply, nil
+	}
+
+	if value == "" || data.PairingParams == nil {
+		return reply, nil
+	}
+
+	switch data.PairingParams.PairingType {
+	case "request-pincode":
+		reply.Pincode = value
+
+	case "request-passkey":
+		passkey, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return reply, fmt.Errorf("Invalid passkey '%s'", value)
+		}
+
+		p := uint32(passkey)
+		reply.Passkey = &p
+	}
+
+	return reply, nil
+}
+
+// hookOutput returns the output of a hook for logging, truncated to a reasonable length.
+func hookOutput(output []byte) string {
+	const limit = 1024
+
+	output = bytes.TrimSpace(output)
+	if len(output) > limit {
+		output = append(output[:limit:limit], "..."...)
+	}
+
+	return string(output)
+}
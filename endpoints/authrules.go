@@ -0,0 +1,509 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+)
+
+// AuthRule describes a rule that automatically replies to the authorization requests it matches.
+type AuthRule struct {
+	ID     string        `json:"id,omitempty" readOnly:"true" doc:"The ID of the rule."`
+	Name   string        `json:"name,omitempty" doc:"A name that describes the rule."`
+	Reply  string        `json:"reply" enum:"yes,no" doc:"The reply to the matching authorization requests."`
+	Reason string        "json:\"reason,omitempty\" doc:\"The reason that is sent if the reply is `no`.\""
+	Match  AuthRuleMatch `json:"match,omitempty" doc:"The criteria that an authorization request must match. Empty criteria match all requests."`
+}
+
+// AuthRuleMatch describes the criteria of an authorization rule. A request matches
+// if it matches all specified criteria, and any of the values of each criterion.
+type AuthRuleMatch struct {
+	AuthTypes    []string `json:"auth_types,omitempty" enum:"pairing,transfer" doc:"The types of the authorization requests."`
+	PairingTypes []string `json:"pairing_types,omitempty" enum:"confirm-passkey,authorize-pairing,authorize-service,request-pincode,request-passkey" doc:"The types of the pairing authorization requests. The 'request-pincode' and 'request-passkey' requests can only be rejected by a rule."`
+	Addresses    []string "json:\"addresses,omitempty\" doc:\"The addresses of the devices, as patterns (for example, `AA:BB:CC:*`).\""
+	DeviceNames  []string "json:\"device_names,omitempty\" doc:\"The names of the devices, as patterns (for example, `Pixel *`).\""
+	Adapters     []string "json:\"adapters,omitempty\" doc:\"The addresses of the adapters that the devices are associated with, as patterns.\""
+	ServiceUUIDs []string `json:"service_uuids,omitempty" doc:"The service profile UUIDs of the 'authorize-service' requests."`
+	FileNames    []string "json:\"file_names,omitempty\" doc:\"The names of the transferred files, as patterns (for example, `*.jpg`).\""
+	Extensions   []string "json:\"extensions,omitempty\" doc:\"The extensions of the transferred files (for example, `.pdf`).\""
+	MinSize      uint64   `json:"min_size,omitempty" doc:"The minimum size of the transferred files, in bytes."`
+	MaxSize      uint64   `json:"max_size,omitempty" doc:"The maximum size of the transferred files, in bytes."`
+}
+
+// AuthRules holds the authorization rules in a file. Rules are evaluated in order,
+// and the first rule that matches an authorization request replies to it.
+type AuthRules struct {
+	path string
+
+	mu      sync.Mutex
+	rules   []AuthRule
+	session bluetooth.Session
+}
+
+// authRuleDevice holds the properties of the device of an authorization request,
+// which are only fetched if a rule requires them.
+type authRuleDevice struct {
+	once       sync.Once
+	name       string
+	adapter    string
+	properties func() (bluetooth.DeviceData, error)
+}
+
+// OpenAuthRules opens the authorization rules file at path. If the file does not exist, there are no rules.
+func OpenAuthRules(path string) (*AuthRules, error) {
+	r := &AuthRules{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return r, nil
+		}
+
+		return nil, fmt.Errorf("Cannot read the authorization rules file '%s': %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &r.rules); err != nil {
+		return nil, fmt.Errorf("Cannot parse the authorization rules file '%s': %w", path, err)
+	}
+
+	// Rules that are written by hand may not have an ID.
+	for i := range r.rules {
+		if err := r.rules[i].validate(); err != nil {
+			return nil, fmt.Errorf("Invalid rule %d in the authorization rules file: %w", i+1, err)
+		}
+
+		if r.rules[i].ID == "" {
+			if r.rules[i].ID, err = newAuthRuleID(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// Rules returns all rules, in the order that they are evaluated in.
+func (r *AuthRules) Rules() []AuthRule {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.rules)
+}
+
+// Rule returns the rule with the provided ID.
+func (r *AuthRules) Rule(id string) (AuthRule, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index := r.index(id)
+	if index < 0 {
+		return AuthRule{}, false
+	}
+
+	return r.rules[index], true
+}
+
+// Create adds the rule at the provided position (starting from 1), or after all rules if the position is 0.
+func (r *AuthRules) Create(rule AuthRule, position int) (AuthRule, error) {
+	if err := rule.validate(); err != nil {
+		return AuthRule{}, err
+	}
+
+	id, err := newAuthRuleID()
+	if err != nil {
+		return AuthRule{}, err
+	}
+	rule.ID = id
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index := position - 1
+	if index < 0 || index > len(r.rules) {
+		index = len(r.rules)
+	}
+
+	return rule, r.save(slices.Insert(slices.Clone(r.rules), index, rule))
+}
+
+// Update replaces the rule with the provided ID, and keeps its position.
+func (r *AuthRules) Update(id string, rule AuthRule) (AuthRule, error) {
+	if err := rule.validate(); err != nil {
+		return AuthRule{}, err
+	}
+	rule.ID = id
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index := r.index(id)
+	if index < 0 {
+		return AuthRule{}, fmt.Errorf("Rule '%s' not found.", id)
+	}
+
+	rules := slices.Clone(r.rules)
+	rules[index] = rule
+
+	return rule, r.save(rules)
+}
+
+// Delete removes the rule with the provided ID.
+func (r *AuthRules) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index := r.index(id)
+	if index < 0 {
+		return fmt.Errorf("Rule '%s' not found.", id)
+	}
+
+	return r.save(slices.Delete(slices.Clone(r.rules), index, index+1))
+}
+
+// start sets the session that the properties of the devices are fetched from.
+func (r *AuthRules) start(session bluetooth.Session) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.session = session
+}
+
+// match returns the first rule that matches the authorization request.
+func (r *AuthRules) match(data AuthRequestEvent) (AuthRule, bool) {
+	if r == nil {
+		return AuthRule{}, false
+	}
+
+	r.mu.Lock()
+	rules, session := r.rules, r.session
+	r.mu.Unlock()
+
+	if len(rules) == 0 {
+		return AuthRule{}, false
+	}
+
+	var address bluetooth.MacAddress
+	switch {
+	case data.PairingParams != nil:
+		address = data.PairingParams.Address
+
+	case data.TransferParams != nil:
+		address = data.TransferParams.FileProperties.Address
+	}
+
+	device := &authRuleDevice{
+		properties: func() (bluetooth.DeviceData, error) {
+			if session == nil {
+				return bluetooth.DeviceData{}, errors.New("The session has not started.")
+			}
+
+			return session.Device(address).Properties()
+		},
+	}
+
+	for _, rule := range rules {
+		// Requests for a PIN code or passkey can only be rejected, since a rule cannot provide a value.
+		if rule.Reply == "yes" && authRequestsValue(data) {
+			continue
+		}
+
+		if rule.Match.matches(data, address, device) {
+			return rule, true
+		}
+	}
+
+	return AuthRule{}, false
+}
+
+func newAuthRuleID() (string, error) {
+	var id [4]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id[:]), nil
+}
+
+func (r *AuthRules) index(id string) int {
+	return slices.IndexFunc(r.rules, func(rule AuthRule) bool {
+		return rule.ID == id
+	})
+}
+
+// save atomically replaces the rules file with the rules.
+func (r *AuthRules) save(rules []AuthRule) error {
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(r.path, data); err != nil {
+		return fmt.Errorf("Cannot write the authorization rules file '%s': %w", r.path, err)
+	}
+
+	r.rules = rules
+
+	return nil
+}
+
+// validate checks whether the rule's reply and patterns are valid.
+func (rule *AuthRule) validate() error {
+	if rule.Reply != "yes" && rule.Reply != "no" {
+		return errors.New("The reply must be either 'yes' or 'no'.")
+	}
+
+	m := rule.Match
+	for _, patterns := range [][]string{m.Addresses, m.DeviceNames, m.Adapters, m.FileNames} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("Invalid pattern '%s'.", pattern)
+			}
+		}
+	}
+
+	for _, id := range m.ServiceUUIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return fmt.Errorf("Invalid service UUID '%s'.", id)
+		}
+	}
+
+	if m.MaxSize > 0 && m.MinSize > m.MaxSize {
+		return errors.New("The minimum size must not be greater than the maximum size.")
+	}
+
+	return nil
+}
+
+// reply returns the reply of the rule to an authorization request.
+func (rule AuthRule) reply() authEventReply {
+	return authEventReply{reply: rule.Reply == "yes", reason: rule.Reason}
+}
+
+// matches reports whether the authorization request matches all specified criteria.
+func (m AuthRuleMatch) matches(data AuthRequestEvent, address bluetooth.MacAddress, device *authRuleDevice) bool {
+	if len(m.AuthTypes) > 0 && !slices.Contains(m.AuthTypes, data.AuthType) {
+		return false
+	}
+
+	if len(m.PairingTypes) > 0 && (data.PairingParams == nil || !slices.Contains(m.PairingTypes, data.PairingParams.PairingType)) {
+		return false
+	}
+
+	if len(m.Addresses) > 0 && !matchPatterns(m.Addresses, strings.ToUpper(address.String()), true) {
+		return false
+	}
+
+	if len(m.ServiceUUIDs) > 0 {
+		if data.PairingParams == nil || data.PairingParams.ServiceUUID == nil {
+			return false
+		}
+
+		if !slices.ContainsFunc(m.ServiceUUIDs, func(id string) bool {
+			return uuid.MustParse(id) == *data.PairingParams.ServiceUUID
+		}) {
+			return false
+		}
+	}
+
+	if len(m.FileNames) > 0 || len(m.Extensions) > 0 || m.MinSize > 0 || m.MaxSize > 0 {
+		if data.TransferParams == nil {
+			return false
+		}
+
+		name := data.TransferParams.FileProperties.Name
+		if name == "" {
+			name = filepath.Base(data.TransferParams.Path)
+		}
+
+		if len(m.FileNames) > 0 && !matchPatterns(m.FileNames, name, false) {
+			return false
+		}
+
+		if len(m.Extensions) > 0 && !slices.ContainsFunc(m.Extensions, func(ext string) bool {
+			return strings.EqualFold("."+strings.TrimPrefix(ext, "."), filepath.Ext(name))
+		}) {
+			return false
+		}
+
+		size := data.TransferParams.FileProperties.Size
+		if size < m.MinSize || (m.MaxSize > 0 && size > m.MaxSize) {
+			return false
+		}
+	}
+
+	if len(m.DeviceNames) > 0 && !matchPatterns(m.DeviceNames, device.Name(), false) {
+		return false
+	}
+
+	if len(m.Adapters) > 0 && !matchPatterns(m.Adapters, device.Adapter(), true) {
+		return false
+	}
+
+	return true
+}
+
+// Name returns the name of the device, or an empty string if it cannot be fetched.
+func (d *authRuleDevice) Name() string {
+	d.fetch()
+
+	return d.name
+}
+
+// Adapter returns the address of the device's adapter, or an empty string if it cannot be fetched.
+func (d *authRuleDevice) Adapter() string {
+	d.fetch()
+
+	return d.adapter
+}
+
+func (d *authRuleDevice) fetch() {
+	d.once.Do(func() {
+		properties, err := d.properties()
+		if err != nil {
+			return
+		}
+
+		d.name, d.adapter = properties.Name, strings.ToUpper(properties.AssociatedAdapter.String())
+	})
+}
+
+// matchPatterns reports whether the value matches any of the patterns.
+func matchPatterns(patterns []string, value string, upper bool) bool {
+	if value == "" {
+		return false
+	}
+
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		if upper {
+			pattern = strings.ToUpper(pattern)
+		}
+
+		matched, _ := path.Match(pattern, value)
+
+		return matched
+	})
+}
+
+func authRulesEndpoints(api huma.API, rules *AuthRules) {
+	type RuleInput struct {
+		ID string `path:"rule_id" doc:"The ID of the rule."`
+	}
+
+	type RuleOutput struct {
+		Body AuthRule
+	}
+
+	type RulesOutput struct {
+		Body []AuthRule
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "auth-rules",
+		Method:      http.MethodGet,
+		Path:        "/auth/rules",
+		Summary:     "List Authorization Rules",
+		Description: "This endpoint fetches all authorization rules, in the order that they are evaluated in. The first rule that matches an authorization request replies to it automatically, and the `auth` event of the request describes the rule.",
+		Security:    tokenScopes(ScopeRead),
+		Tags:        []string{"Authorization Rules"},
+	}, func(_ context.Context, _ *struct{}) (*RulesOutput, error) {
+		return &RulesOutput{rules.Rules()}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "auth-rule-create",
+		Method:        http.MethodPost,
+		Path:          "/auth/rules",
+		Summary:       "Create Authorization Rule",
+		Description:   "This endpoint creates an authorization rule, which is evaluated after all existing rules, unless a position is provided.",
+		DefaultStatus: http.StatusCreated,
+		Security:      tokenScopes(ScopeAuthReply),
+		Tags:          []string{"Authorization Rules"},
+		Metadata:      map[string]any{operationMutating: true},
+	}, func(_ context.Context, input *struct {
+		Position int `query:"position" minimum:"0" doc:"The position to insert the rule at, starting from 1. By default, the rule is appended."`
+		Body     AuthRule
+	}) (*RuleOutput, error) {
+		if err := input.Body.validate(); err != nil {
+			return nil, huma.Error422UnprocessableEntity(err.Error())
+		}
+
+		rule, err := rules.Create(input.Body, input.Position)
+
+		return &RuleOutput{rule}, err
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "auth-rule",
+		Method:      http.MethodGet,
+		Path:        "/auth/rules/{rule_id}",
+		Summary:     "Get Authorization Rule",
+		Description: "This endpoint fetches an authorization rule.",
+		Security:    tokenScopes(ScopeRead),
+		Tags:        []string{"Authorization Rules"},
+	}, func(_ context.Context, input *RuleInput) (*RuleOutput, error) {
+		rule, ok := rules.Rule(input.ID)
+		if !ok {
+			return nil, huma.Error404NotFound(fmt.Sprintf("Rule '%s' not found.", input.ID))
+		}
+
+		return &RuleOutput{rule}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "auth-rule-update",
+		Method:      http.MethodPut,
+		Path:        "/auth/rules/{rule_id}",
+		Summary:     "Update Authorization Rule",
+		Description: "This endpoint replaces an authorization rule, and keeps its position.",
+		Security:    tokenScopes(ScopeAuthReply),
+		Tags:        []string{"Authorization Rules"},
+		Metadata:    map[string]any{operationMutating: true},
+	}, func(_ context.Context, input *struct {
+		RuleInput
+		Body AuthRule
+	}) (*RuleOutput, error) {
+		if _, ok := rules.Rule(input.ID); !ok {
+			return nil, huma.Error404NotFound(fmt.Sprintf("Rule '%s' not found.", input.ID))
+		}
+
+		if err := input.Body.validate(); err != nil {
+			return nil, huma.Error422UnprocessableEntity(err.Error())
+		}
+
+		rule, err := rules.Update(input.ID, input.Body)
+
+		return &RuleOutput{rule}, err
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "auth-rule-delete",
+		Method:        http.MethodDelete,
+		Path:          "/auth/rules/{rule_id}",
+		Summary:       "Delete Authorization Rule",
+		Description:   "This endpoint deletes an authorization rule.",
+		DefaultStatus: http.StatusNoContent,
+		Security:      tokenScopes(ScopeAuthReply),
+		Tags:          []string{"Authorization Rules"},
+		Metadata:      map[string]any{operationMutating: true},
+	}, func(_ context.Context, input *RuleInput) (*struct{}, error) {
+		if _, ok := rules.Rule(input.ID); !ok {
+			return nil, huma.Error404NotFound(fmt.Sprintf("Rule '%s' not found.", input.ID))
+		}
+
+		return nil, rules.Delete(input.ID)
+	})
+}
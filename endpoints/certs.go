@@ -0,0 +1,73 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// CertPolicy authorizes TLS clients using the common name of their verified client
+// certificate. Each scope has an allowlist of names, and a client is granted a scope
+// if the name of its certificate is in the allowlist of the scope.
+type CertPolicy struct {
+	names map[string][]string
+}
+
+// NewCertPolicy parses the allowlist rules. Each rule is of the form 'scope=name,...',
+// where scope is one of the token scopes (or '*' for all scopes), and each name is the
+// common name of a client certificate.
+func NewCertPolicy(rules []string) (*CertPolicy, error) {
+	p := &CertPolicy{names: make(map[string][]string)}
+
+	for _, rule := range rules {
+		scopes, names, err := parseScopeRule(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, scope := range scopes {
+			p.names[scope] = append(p.names[scope], names...)
+		}
+	}
+
+	return p, nil
+}
+
+// ClientCertificateFromContext returns the common name of the verified client certificate
+// that the TLS client, which made the call, has presented.
+func ClientCertificateFromContext(ctx context.Context) (string, bool) {
+	conn, ok := ctx.Value(tlsConnKey{}).(*tls.Conn)
+	if !ok {
+		return "", false
+	}
+
+	state := conn.ConnectionState()
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	return state.VerifiedChains[0][0].Subject.CommonName, true
+}
+
+// authorize checks whether the certificate name has been granted the scope.
+// If scope is empty, the name must have been granted any scope.
+func (p *CertPolicy) authorize(name, scope string) (int, string) {
+	scopes := []string{scope}
+	if scope == "" {
+		scopes = TokenScopes
+	}
+
+	for _, scope := range scopes {
+		if slices.Contains(p.names[scope], name) {
+			return http.StatusOK, ""
+		}
+	}
+
+	if scope == "" {
+		return http.StatusForbidden, fmt.Sprintf("The client certificate '%s' is not allowed to use the API.", name)
+	}
+
+	return http.StatusForbidden, fmt.Sprintf("The client certificate '%s' is not allowed the '%s' scope.", name, scope)
+}
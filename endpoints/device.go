@@ -28,6 +28,7 @@ func devicePropertiesEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/device/{address}/properties",
 		Summary:     "Properties",
 		Description: "This endpoint fetches the properties of the device.",
+		Security:    tokenScopes(ScopeRead),
 		Tags:        []string{"Device"},
 	}, func(_ context.Context, input *struct {
 		AddressInput
@@ -47,13 +48,15 @@ func removeEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/device/{address}/remove",
 		Summary:     "Remove",
 		Description: "This endpoint removes a device from its associated adapter.",
+		Security:    tokenScopes(ScopePairing),
 		Tags:        []string{"Device"},
-	}, func(_ context.Context, input *struct {
+		Metadata:    map[string]any{operationMutating: true},
+	}, func(ctx context.Context, input *struct {
 		AddressInput
 	}) (*struct{}, error) {
 		deviceCall := session.Device(input.Address)
 
-		return nil, deviceCall.Remove()
+		return nil, sessionCall(ctx, "device.Remove", input.Address, deviceCall.Remove)
 	})
 }
 
@@ -64,18 +67,20 @@ func pairEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/device/{address}/pair",
 		Summary:     "Pairing",
 		Description: "This endpoint starts a pairing process to an unpaired device in pairing mode. If the `cancel` parameter is specified, an ongoing pairing operation to the device, if it exists, will be stopped.",
+		Security:    tokenScopes(ScopePairing),
 		Tags:        []string{"Device"},
-	}, func(_ context.Context, input *struct {
+		Metadata:    map[string]any{operationMutating: true},
+	}, func(ctx context.Context, input *struct {
 		AddressInput
 		Cancel bool `query:"cancel" doc:"Specifies if an ongoing pairing operation to the device should be cancelled."`
 	}) (*struct{}, error) {
 		deviceCall := session.Device(input.Address)
 
 		if input.Cancel {
-			return nil, deviceCall.CancelPairing()
+			return nil, sessionCall(ctx, "device.CancelPairing", input.Address, deviceCall.CancelPairing)
 		}
 
-		return nil, deviceCall.Pair()
+		return nil, sessionCall(ctx, "device.Pair", input.Address, deviceCall.Pair)
 	})
 }
 
@@ -86,18 +91,22 @@ func connectEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/device/{address}/connect",
 		Summary:     "Connection",
 		Description: "This endpoint starts a connection process to a paired device. If a service profile UUID is specified, it will attempt to connect to it, otherwise a profile will be chosen and connected to automatically.",
+		Security:    tokenScopes(ScopeControl),
 		Tags:        []string{"Device"},
-	}, func(_ context.Context, input *struct {
+		Metadata:    map[string]any{operationMutating: true},
+	}, func(ctx context.Context, input *struct {
 		AddressInput
 		UUID uuid.UUID `query:"profile_uuid" format:"uuid" doc:"The Bluetooth service profile UUID."`
 	}) (*struct{}, error) {
 		deviceCall := session.Device(input.Address)
 
 		if input.UUID != uuid.Nil {
-			return nil, deviceCall.ConnectProfile(input.UUID)
+			return nil, sessionCall(ctx, "device.ConnectProfile", input.Address, func() error {
+				return deviceCall.ConnectProfile(input.UUID)
+			})
 		}
 
-		return nil, deviceCall.Connect()
+		return nil, sessionCall(ctx, "device.Connect", input.Address, deviceCall.Connect)
 	})
 }
 
@@ -108,17 +117,21 @@ func disconnectEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/device/{address}/disconnect",
 		Summary:     "Disconnection",
 		Description: "This endpoint starts a disconnection process from a paired device. If a service profile UUID is specified, it will attempt to disconnect from it.",
+		Security:    tokenScopes(ScopeControl),
 		Tags:        []string{"Device"},
-	}, func(_ context.Context, input *struct {
+		Metadata:    map[string]any{operationMutating: true},
+	}, func(ctx context.Context, input *struct {
 		AddressInput
 		UUID uuid.UUID `query:"profile_uuid" format:"uuid" doc:"The Bluetooth service profile UUID."`
 	}) (*struct{}, error) {
 		deviceCall := session.Device(input.Address)
 
 		if input.UUID != uuid.Nil {
-			return nil, deviceCall.DisconnectProfile(input.UUID)
+			return nil, sessionCall(ctx, "device.DisconnectProfile", input.Address, func() error {
+				return deviceCall.DisconnectProfile(input.UUID)
+			})
 		}
 
-		return nil, deviceCall.Disconnect()
+		return nil, sessionCall(ctx, "device.Disconnect", input.Address, deviceCall.Disconnect)
 	})
 }
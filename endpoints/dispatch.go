@@ -0,0 +1,253 @@
+package endpoints
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// dispatcher calls the registered API operations by their operation ID,
+// for use by transports other than plain HTTP requests. Each call is served
+// through the API's router, so that all input validation and middlewares apply.
+type dispatcher struct {
+	api     huma.API
+	handler http.Handler
+	access  *accessControl
+
+	once       sync.Once
+	operations map[string]*huma.Operation
+}
+
+// dispatchWriter collects the response of a dispatched call.
+type dispatchWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+// dispatchKey marks the context of a request as a dispatched call.
+type dispatchKey struct{}
+
+// dispatchRequest describes a call to an API operation.
+type dispatchRequest struct {
+	OperationID string          `json:"operation" doc:"The ID of the operation to call, as listed in the OpenAPI specification."`
+	Params      map[string]any  `json:"params,omitempty" doc:"The path and query parameters of the operation."`
+	Body        json.RawMessage `json:"body,omitempty" doc:"The request body of the operation, if required."`
+}
+
+// dispatchResponse describes the result of a call to an API operation.
+type dispatchResponse struct {
+	Status int             `json:"status" doc:"The HTTP status code of the operation's response."`
+	Data   json.RawMessage `json:"data,omitempty" doc:"The response body of the operation, if the call was successful."`
+	Error  json.RawMessage `json:"error,omitempty" doc:"The error details, if the call was unsuccessful."`
+}
+
+// streamingOperations are operations which stream responses, and cannot be dispatched.
+var streamingOperations = []string{"events", "websocket"}
+
+func newDispatcher(api huma.API, handler http.Handler, access *accessControl) *dispatcher {
+	return &dispatcher{api: api, handler: handler, access: access}
+}
+
+// Operations returns all operations that can be dispatched, keyed by their operation ID.
+func (d *dispatcher) Operations() map[string]*huma.Operation {
+	d.once.Do(func() {
+		d.operations = make(map[string]*huma.Operation)
+
+		for _, path := range d.api.OpenAPI().Paths {
+			for _, op := range []*huma.Operation{
+				path.Get, path.Post, path.Put, path.Patch, path.Delete,
+			} {
+				if op == nil || op.OperationID == "" || op.Hidden {
+					continue
+				}
+
+				if slices.Contains(streamingOperations, op.OperationID) {
+					continue
+				}
+
+				d.operations[op.OperationID] = op
+			}
+		}
+	})
+
+	return d.operations
+}
+
+// Call calls the operation specified in the request. The provided header is sent
+// along with the request, for example to pass on the caller's credentials.
+func (d *dispatcher) Call(ctx context.Context, header http.Header, req dispatchRequest) (dispatchResponse, error) {
+	op, ok := d.Operations()[req.OperationID]
+	if !ok {
+		return dispatchResponse{}, fmt.Errorf("Unknown operation '%s'", req.OperationID)
+	}
+
+	path := op.Path
+	query := url.Values{}
+	for name, value := range req.Params {
+		v, err := paramString(value)
+		if err != nil {
+			return dispatchResponse{}, fmt.Errorf("Invalid parameter '%s': %w", name, err)
+		}
+
+		if placeholder := "{" + name + "}"; strings.Contains(path, placeholder) {
+			path = strings.ReplaceAll(path, placeholder, url.PathEscape(v))
+			continue
+		}
+
+		query.Set(name, v)
+	}
+
+	if strings.Contains(path, "{") {
+		return dispatchResponse{}, fmt.Errorf("Missing path parameters for operation '%s' (%s)", req.OperationID, op.Path)
+	}
+
+	target := path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	ctx = context.WithValue(ctx, dispatchKey{}, true)
+
+	r, err := http.NewRequestWithContext(ctx, op.Method, target, bytes.NewReader(req.Body))
+	if err != nil {
+		return dispatchResponse{}, err
+	}
+
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	if req.Body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+
+	w := &dispatchWriter{header: make(http.Header)}
+	d.handler.ServeHTTP(w, r)
+
+	resp := dispatchResponse{Status: cmp.Or(w.status, http.StatusOK)}
+	if data := bytes.TrimSpace(w.body.Bytes()); len(data) > 0 {
+		if !json.Valid(data) {
+			data, _ = json.Marshal(string(data))
+		}
+
+		if resp.Status >= http.StatusBadRequest {
+			resp.Error = data
+		} else {
+			resp.Data = data
+		}
+	}
+
+	return resp, nil
+}
+
+// dispatched reports whether the request in the context is a call that is dispatched by
+// a transport other than plain HTTP requests, which browsers cannot send without a preflight.
+func dispatched(ctx context.Context) bool {
+	d, _ := ctx.Value(dispatchKey{}).(bool)
+
+	return d
+}
+
+// Authorize checks whether the caller in the context, or the credentials in the header,
+// have been granted the scope, for streams that are not dispatched as operations.
+// If the caller is not authorized, the status and a description of the error is returned.
+func (d *dispatcher) Authorize(ctx context.Context, header http.Header, scope string) (int, string) {
+	return d.access.authorize(ctx, header.Get("Authorization"), "", scope)
+}
+
+// ErrorMessage returns a readable message describing the error of an unsuccessful call,
+// which includes the error details and the location of each invalid parameter.
+func (r dispatchResponse) ErrorMessage() string {
+	message := http.StatusText(r.Status)
+	if text := ""; json.Unmarshal(r.Error, &text) == nil {
+		if text != "" {
+			message = text
+		}
+
+		return message
+	}
+
+	var model struct {
+		Detail string `json:"detail"`
+		Errors []struct {
+			Message  string `json:"message"`
+			Location string `json:"location"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(r.Error, &model) == nil {
+		details := make([]string, 0, len(model.Errors)+1)
+		if model.Detail != "" {
+			details = append(details, model.Detail)
+		}
+		for _, e := range model.Errors {
+			if e.Location != "" {
+				e.Message = e.Location + ": " + e.Message
+			}
+
+			details = append(details, e.Message)
+		}
+
+		if len(details) > 0 {
+			message = strings.Join(details, "; ")
+		}
+	}
+
+	return message
+}
+
+func (w *dispatchWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *dispatchWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *dispatchWriter) Write(data []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+
+	return w.body.Write(data)
+}
+
+// paramString converts a JSON parameter value to its string representation.
+// Arrays are converted to comma-separated values.
+func paramString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+
+	case bool:
+		return strconv.FormatBool(v), nil
+
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := paramString(item)
+			if err != nil {
+				return "", err
+			}
+
+			values = append(values, s)
+		}
+
+		return strings.Join(values, ","), nil
+	}
+
+	return "", errors.New("unsupported parameter type")
+}
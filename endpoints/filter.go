@@ -0,0 +1,169 @@
+package endpoints
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// EventFilterInput describes the query parameters used to filter events.
+type EventFilterInput struct {
+	Types     []string `query:"type" enum:"auth,adapter,error,device,mediaplayer,filetransfer,presence" doc:"Only receive events with the specified event names."`
+	Addresses []string `query:"address" doc:"Only receive events associated with the specified device or adapter Bluetooth MAC addresses."`
+	Actions   []string `query:"action" enum:"added,removed,updated,arrived,left" doc:"Only receive events with the specified event actions."`
+
+	addresses []string
+}
+
+// eventFilter matches events against a set of event names, addresses and actions.
+// An empty set matches all events.
+type eventFilter struct {
+	types     []string
+	addresses []string
+	actions   []string
+}
+
+// eventProperties holds the properties of an event's data that can be filtered on.
+type eventProperties struct {
+	action    string
+	adapter   string
+	device    string
+	addresses []string
+}
+
+func (e *EventFilterInput) Resolve(_ huma.Context) []error {
+	var errs []error
+
+	e.addresses = make([]string, 0, len(e.Addresses))
+	for _, address := range e.Addresses {
+		mac, err := bluetooth.ParseMAC(address)
+		if err != nil {
+			errs = append(errs, &huma.ErrorDetail{
+				Message:  err.Error(),
+				Location: "query.address",
+				Value:    address,
+			})
+
+			continue
+		}
+
+		e.addresses = append(e.addresses, mac.String())
+	}
+
+	return errs
+}
+
+// parseEventFilter parses an event filter from the query parameters
+// described by EventFilterInput.
+func parseEventFilter(query url.Values) (eventFilter, error) {
+	split := func(name string) []string {
+		if value := query.Get(name); value != "" {
+			return strings.Split(value, ",")
+		}
+
+		return nil
+	}
+
+	return newEventFilter(split("type"), split("address"), split("action"))
+}
+
+// newEventFilter returns an event filter for the provided event names, addresses and actions.
+func newEventFilter(types, addresses, actions []string) (eventFilter, error) {
+	filter := eventFilter{types: types, actions: actions}
+
+	for _, address := range addresses {
+		mac, err := bluetooth.ParseMAC(address)
+		if err != nil {
+			return filter, fmt.Errorf("Invalid address '%s': %w", address, err)
+		}
+
+		filter.addresses = append(filter.addresses, mac.String())
+	}
+
+	return filter, nil
+}
+
+func (e *EventFilterInput) Filter() eventFilter {
+	return eventFilter{
+		types:     e.Types,
+		addresses: e.addresses,
+		actions:   e.Actions,
+	}
+}
+
+// receives reports whether events with the name are not filtered out by their name.
+func (f eventFilter) receives(name string) bool {
+	return len(f.types) == 0 || slices.Contains(f.types, name)
+}
+
+// Match reports whether the event matches the filter.
+func (f eventFilter) Match(event hubEvent) bool {
+	if event.Name == "gap" {
+		return true
+	}
+
+	if len(f.types) > 0 && !slices.Contains(f.types, event.Name) {
+		return false
+	}
+
+	if len(f.addresses) == 0 && len(f.actions) == 0 {
+		return true
+	}
+
+	props := event.Properties()
+	if len(f.actions) > 0 && !slices.Contains(f.actions, props.action) {
+		return false
+	}
+
+	if len(f.addresses) > 0 {
+		return slices.ContainsFunc(props.addresses, func(address string) bool {
+			return slices.ContainsFunc(f.addresses, func(a string) bool {
+				return strings.EqualFold(a, address)
+			})
+		})
+	}
+
+	return true
+}
+
+// Properties returns the action and the addresses associated with the event.
+// For adapter events, the address of the event is the adapter's address.
+func (e hubEvent) Properties() eventProperties {
+	var props eventProperties
+
+	switch data := e.Data.(type) {
+	case bluetooth.AdapterEventData:
+		props.action, props.adapter = data.Action.String(), data.Address.String()
+
+	case bluetooth.DeviceEventData:
+		props.action = data.Action.String()
+		props.device, props.adapter = data.Address.String(), data.AssociatedAdapter.String()
+
+	case bluetooth.MediaEventData:
+		props.action = data.Action.String()
+
+	case bluetooth.FileTransferEventData:
+		props.action, props.device = data.Action.String(), data.Address.String()
+
+	case AuthRequestEvent:
+		props.action, props.device = data.Action, authRequestAddress(data)
+
+	case authErrorEventData:
+		props.device = data.Address
+
+	case presenceEventData:
+		props.action, props.device = data.Action, data.Device.Address.String()
+	}
+
+	for _, address := range []string{props.device, props.adapter} {
+		if address != "" && !slices.Contains(props.addresses, address) {
+			props.addresses = append(props.addresses, address)
+		}
+	}
+
+	return props
+}
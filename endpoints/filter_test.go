@@ -0,0 +1,79 @@
+package endpoints
+
+import (
+	"testing"
+
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+)
+
+func TestEventFilterMatch(t *testing.T) {
+	const (
+		adapter = "00:11:22:33:44:55"
+		device  = "AA:BB:CC:DD:EE:FF"
+	)
+
+	mac := func(address string) bluetooth.MacAddress {
+		m, err := bluetooth.ParseMAC(address)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return m
+	}
+
+	deviceAdded := hubEvent{Name: "device", Data: bluetooth.DeviceEventData{
+		Action:     "added",
+		DeviceData: bluetooth.DeviceData{Address: mac(device), AssociatedAdapter: mac(adapter)},
+	}}
+	adapterUpdated := hubEvent{Name: "adapter", Data: bluetooth.AdapterEventData{
+		Action:      "updated",
+		AdapterData: bluetooth.AdapterData{Address: mac(adapter)},
+	}}
+	authRequested := hubEvent{Name: "auth", Data: AuthRequestEvent{
+		Action:        "requested",
+		PairingParams: &authPairingEvent{PairingType: "confirm-passkey", Address: mac(device)},
+	}}
+	gap := hubEvent{Name: "gap", Data: eventGap{}}
+
+	tests := []struct {
+		name      string
+		types     []string
+		addresses []string
+		actions   []string
+		event     hubEvent
+		want      bool
+	}{
+		{name: "empty filter", event: deviceAdded, want: true},
+		{name: "type", types: []string{"device"}, event: deviceAdded, want: true},
+		{name: "other type", types: []string{"adapter"}, event: deviceAdded, want: false},
+		{name: "action", actions: []string{"added"}, event: deviceAdded, want: true},
+		{name: "other action", actions: []string{"removed"}, event: deviceAdded, want: false},
+		{name: "device address", addresses: []string{device}, event: deviceAdded, want: true},
+		{name: "lowercase address", addresses: []string{"aa:bb:cc:dd:ee:ff"}, event: deviceAdded, want: true},
+		{name: "associated adapter", addresses: []string{adapter}, event: deviceAdded, want: true},
+		{name: "adapter address", addresses: []string{adapter}, event: adapterUpdated, want: true},
+		{name: "other address", addresses: []string{device}, event: adapterUpdated, want: false},
+		{name: "auth device", types: []string{"auth"}, addresses: []string{device}, actions: []string{"requested"}, event: authRequested, want: true},
+		{name: "auth other action", types: []string{"auth"}, actions: []string{"answered"}, event: authRequested, want: false},
+		{name: "gap", types: []string{"auth"}, addresses: []string{device}, event: gap, want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := newEventFilter(test.types, test.addresses, test.actions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := filter.Match(test.event); got != test.want {
+				t.Errorf("Match returned %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNewEventFilterInvalidAddress(t *testing.T) {
+	if _, err := newEventFilter(nil, []string{"not-an-address"}, nil); err == nil {
+		t.Error("Filter was created with an invalid address")
+	}
+}
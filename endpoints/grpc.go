@@ -0,0 +1,523 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bluetuith-org/daemon/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const grpcStopTimeout = 5 * time.Second
+
+// GRPCServer serves the gRPC API described in the rpc package, on its own listener.
+// Each unary RPC is dispatched to its corresponding REST operation, so that all input
+// validation and middlewares apply, and the Subscribe RPC streams events from the hub.
+type GRPCServer struct {
+	rpc.UnimplementedBluerestdServer
+
+	listener net.Listener
+	server   *grpc.Server
+
+	hub   *eventHub
+	calls *dispatcher
+}
+
+// grpcForwardedMetadata are the request metadata keys that are forwarded
+// as headers to every operation called by a gRPC client.
+var grpcForwardedMetadata = []string{"authorization"}
+
+// grpcConnCredentials passes the connection of each client to the calls of the server as its
+// authentication info, so that the peer credentials of UNIX socket clients and the TLS connection
+// of TLS clients are available to authorize the calls. TLS connections are provided by the listener,
+// and their handshake is completed before the connection is used.
+type grpcConnCredentials struct{}
+
+// grpcConnInfo is the authentication info of a client, which holds its connection.
+type grpcConnInfo struct {
+	credentials.CommonAuthInfo
+	conn net.Conn
+}
+
+// grpcConnStream is a server stream with the context of its connection.
+type grpcConnStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// NewGRPCServer returns a new gRPC server. It starts serving on the listener
+// only after the API is registered.
+func NewGRPCServer(listener net.Listener) *GRPCServer {
+	return &GRPCServer{
+		listener: listener,
+		server: grpc.NewServer(
+			grpc.Creds(grpcConnCredentials{}),
+			grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				return handler(grpcConnContext(ctx), req)
+			}),
+			grpc.StreamInterceptor(func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				return handler(srv, &grpcConnStream{stream, grpcConnContext(stream.Context())})
+			}),
+		),
+	}
+}
+
+// Close stops the server, after waiting for a while for all pending RPCs to finish.
+// The listener is closed, even if the server was never started.
+func (g *GRPCServer) Close() {
+	defer g.listener.Close()
+
+	stopped := make(chan struct{})
+	go func() {
+		g.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(grpcStopTimeout):
+		g.server.Stop()
+	}
+}
+
+// start registers the service and serves it in the background.
+func (g *GRPCServer) start(hub *eventHub, calls *dispatcher) {
+	g.hub, g.calls = hub, calls
+
+	rpc.RegisterBluerestdServer(g.server, g)
+	go g.server.Serve(g.listener)
+}
+
+func (g *GRPCServer) Adapters(ctx context.Context, _ *emptypb.Empty) (*rpc.AdaptersResponse, error) {
+	return grpcCall(ctx, g, dispatchRequest{OperationID: "adapters"}, "adapters", &rpc.AdaptersResponse{})
+}
+
+func (g *GRPCServer) AdapterProperties(ctx context.Context, req *rpc.AddressRequest) (*rpc.PropertiesResponse, error) {
+	return grpcCall(ctx, g, addressRequest("adapter-properties", req), "properties", &rpc.PropertiesResponse{})
+}
+
+func (g *GRPCServer) AdapterDevices(ctx context.Context, req *rpc.AddressRequest) (*rpc.DevicesResponse, error) {
+	return grpcCall(ctx, g, addressRequest("adapter-devices", req), "devices", &rpc.DevicesResponse{})
+}
+
+func (g *GRPCServer) AdapterStates(ctx context.Context, req *rpc.AdapterStatesRequest) (*rpc.AdapterStatesResponse, error) {
+	params := map[string]any{"address": req.GetAddress()}
+	for name, state := range map[string]*bool{
+		"powered":      req.Powered,
+		"pairable":     req.Pairable,
+		"discoverable": req.Discoverable,
+		"discovery":    req.Discovery,
+	} {
+		if state == nil {
+			continue
+		}
+
+		params[name] = "disable"
+		if *state {
+			params[name] = "enable"
+		}
+	}
+
+	return grpcCall(ctx, g, dispatchRequest{OperationID: "adapter-states", Params: params}, "", &rpc.AdapterStatesResponse{})
+}
+
+func (g *GRPCServer) DeviceProperties(ctx context.Context, req *rpc.AddressRequest) (*rpc.PropertiesResponse, error) {
+	return grpcCall(ctx, g, addressRequest("device-properties", req), "properties", &rpc.PropertiesResponse{})
+}
+
+func (g *GRPCServer) DevicePair(ctx context.Context, req *rpc.AddressRequest) (*emptypb.Empty, error) {
+	return grpcCall(ctx, g, addressRequest("device-pair", req), "", &emptypb.Empty{})
+}
+
+func (g *GRPCServer) DeviceConnect(ctx context.Context, req *rpc.AddressRequest) (*emptypb.Empty, error) {
+	return grpcCall(ctx, g, addressRequest("device-connect", req), "", &emptypb.Empty{})
+}
+
+func (g *GRPCServer) DeviceDisconnect(ctx context.Context, req *rpc.AddressRequest) (*emptypb.Empty, error) {
+	return grpcCall(ctx, g, addressRequest("device-disconnect", req), "", &emptypb.Empty{})
+}
+
+func (g *GRPCServer) DeviceRemove(ctx context.Context, req *rpc.AddressRequest) (*emptypb.Empty, error) {
+	return grpcCall(ctx, g, addressRequest("device-remove", req), "", &emptypb.Empty{})
+}
+
+func (g *GRPCServer) MediaPlayerProperties(ctx context.Context, req *rpc.AddressRequest) (*rpc.PropertiesResponse, error) {
+	return grpcCall(ctx, g, addressRequest("device-media-player-properties", req), "properties", &rpc.PropertiesResponse{})
+}
+
+func (g *GRPCServer) MediaPlayerControl(ctx context.Context, req *rpc.MediaPlayerControlRequest) (*emptypb.Empty, error) {
+	return grpcCall(ctx, g, dispatchRequest{
+		OperationID: "device-media-player-controls",
+		Params: map[string]any{
+			"address":      req.GetAddress(),
+			"control_type": req.GetControlType(),
+		},
+	}, "", &emptypb.Empty{})
+}
+
+func (g *GRPCServer) NetworkConnect(ctx context.Context, req *rpc.NetworkConnectRequest) (*emptypb.Empty, error) {
+	connectionType := req.GetConnectionType()
+	if connectionType == "" {
+		connectionType = "panu"
+	}
+
+	return grpcCall(ctx, g, dispatchRequest{
+		OperationID: "device-network-connect",
+		Params: map[string]any{
+			"address":         req.GetAddress(),
+			"connection_type": connectionType,
+		},
+	}, "", &emptypb.Empty{})
+}
+
+func (g *GRPCServer) NetworkDisconnect(ctx context.Context, req *rpc.AddressRequest) (*emptypb.Empty, error) {
+	return grpcCall(ctx, g, addressRequest("device-network-disconnect", req), "", &emptypb.Empty{})
+}
+
+func (g *GRPCServer) FileTransferStart(ctx context.Context, req *rpc.FileTransferStartRequest) (*rpc.FileTransferStartResponse, error) {
+	body, err := json.Marshal(map[string][]string{"file_paths": req.GetFilePaths()})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return grpcCall(ctx, g, dispatchRequest{
+		OperationID: "file-transfer-start",
+		Params:      map[string]any{"address": req.GetAddress()},
+		Body:        body,
+	}, "", &rpc.FileTransferStartResponse{})
+}
+
+func (g *GRPCServer) FileTransferStop(ctx context.Context, req *rpc.AddressRequest) (*emptypb.Empty, error) {
+	return grpcCall(ctx, g, addressRequest("file-transfer-stop", req), "", &emptypb.Empty{})
+}
+
+func (g *GRPCServer) AuthRequests(ctx context.Context, _ *emptypb.Empty) (*rpc.AuthRequestsResponse, error) {
+	return grpcCall(ctx, g, dispatchRequest{OperationID: "auth-requests"}, "requests", &rpc.AuthRequestsResponse{})
+}
+
+func (g *GRPCServer) AuthRequest(ctx context.Context, req *rpc.AuthIDRequest) (*rpc.AuthRequestResponse, error) {
+	return grpcCall(ctx, g, dispatchRequest{
+		OperationID: "auth-request",
+		Params:      map[string]any{"auth_id": strconv.FormatInt(req.GetAuthId(), 10)},
+	}, "request", &rpc.AuthRequestResponse{})
+}
+
+func (g *GRPCServer) AuthReply(ctx context.Context, req *rpc.AuthReplyRequest) (*emptypb.Empty, error) {
+	body, err := json.Marshal(authReplyBody{
+		Reply:   req.GetReply(),
+		Reason:  req.GetReason(),
+		Pincode: req.GetPincode(),
+		Passkey: req.Passkey,
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return grpcCall(ctx, g, dispatchRequest{
+		OperationID: "auth-reply",
+		Params:      map[string]any{"auth_id": strconv.FormatInt(req.GetAuthId(), 10)},
+		Body:        body,
+	}, "", &emptypb.Empty{})
+}
+
+func (g *GRPCServer) AuthRules(ctx context.Context, _ *emptypb.Empty) (*rpc.AuthRulesResponse, error) {
+	return grpcCall(ctx, g, dispatchRequest{OperationID: "auth-rules"}, "rules", &rpc.AuthRulesResponse{})
+}
+
+func (g *GRPCServer) AuthRule(ctx context.Context, req *rpc.AuthRuleIDRequest) (*rpc.AuthRuleResponse, error) {
+	return grpcCall(ctx, g, authRuleRequest("auth-rule", req.GetRuleId()), "rule", &rpc.AuthRuleResponse{})
+}
+
+func (g *GRPCServer) AuthRuleCreate(ctx context.Context, req *rpc.AuthRuleCreateRequest) (*rpc.AuthRuleResponse, error) {
+	body, err := protojson.Marshal(req.GetRule())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	params := map[string]any{}
+	if req.GetPosition() > 0 {
+		params["position"] = strconv.Itoa(int(req.GetPosition()))
+	}
+
+	return grpcCall(ctx, g, dispatchRequest{OperationID: "auth-rule-create", Params: params, Body: body}, "rule", &rpc.AuthRuleResponse{})
+}
+
+func (g *GRPCServer) AuthRuleUpdate(ctx context.Context, req *rpc.AuthRuleUpdateRequest) (*rpc.AuthRuleResponse, error) {
+	body, err := protojson.Marshal(req.GetRule())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	call := authRuleRequest("auth-rule-update", req.GetRuleId())
+	call.Body = body
+
+	return grpcCall(ctx, g, call, "rule", &rpc.AuthRuleResponse{})
+}
+
+func (g *GRPCServer) AuthRuleDelete(ctx context.Context, req *rpc.AuthRuleIDRequest) (*emptypb.Empty, error) {
+	return grpcCall(ctx, g, authRuleRequest("auth-rule-delete", req.GetRuleId()), "", &emptypb.Empty{})
+}
+
+func (g *GRPCServer) Info(ctx context.Context, _ *emptypb.Empty) (*rpc.InfoResponse, error) {
+	return grpcCall(ctx, g, dispatchRequest{OperationID: "info"}, "info", &rpc.InfoResponse{})
+}
+
+func (g *GRPCServer) Presence(ctx context.Context, req *rpc.PresenceRequest) (*rpc.PresenceResponse, error) {
+	return grpcCall(ctx, g, dispatchRequest{
+		OperationID: "presence",
+		Params:      map[string]any{"present": req.GetPresent()},
+	}, "devices", &rpc.PresenceResponse{})
+}
+
+func (g *GRPCServer) Journal(ctx context.Context, req *rpc.JournalRequest) (*rpc.JournalResponse, error) {
+	params := map[string]any{}
+	for name, value := range map[string]string{
+		"since":   req.GetSince(),
+		"until":   req.GetUntil(),
+		"type":    strings.Join(req.GetType(), ","),
+		"address": strings.Join(req.GetAddress(), ","),
+	} {
+		if value != "" {
+			params[name] = value
+		}
+	}
+	if req.GetLimit() > 0 {
+		params["limit"] = strconv.Itoa(int(req.GetLimit()))
+	}
+
+	return grpcCall(ctx, g, dispatchRequest{OperationID: "journal", Params: params}, "entries", &rpc.JournalResponse{})
+}
+
+func (g *GRPCServer) Subscribe(req *rpc.SubscribeRequest, stream grpc.ServerStreamingServer[rpc.Event]) error {
+	filter, err := newEventFilter(req.GetType(), req.GetAddress(), req.GetAction())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if code, message := g.calls.Authorize(stream.Context(), grpcHeader(stream.Context()), ScopeRead); code != http.StatusOK {
+		data, _ := json.Marshal(message)
+		return grpcError(dispatchResponse{Status: code, Error: data})
+	}
+
+	sub := g.hub.Subscribe(uint(req.GetLastEventId()))
+	defer g.hub.Unsubscribe(sub)
+
+	if req.GetAgent() && filter.receives("auth") {
+		defer registerAuthAgent()()
+	}
+
+	sendEvents := func(events []hubEvent) error {
+		for _, event := range events {
+			if !filter.Match(event) {
+				continue
+			}
+
+			data, err := grpcValue(event.Data)
+			if err != nil {
+				continue
+			}
+
+			if err := stream.Send(&rpc.Event{
+				Id:    uint64(event.ID),
+				Event: event.Name,
+				Data:  data,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := sendEvents(sub.Replay()); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+
+		case event := <-sub.events:
+			if err := sendEvents(sub.Next(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// grpcConnContext adds the peer credentials or the TLS connection of the client to the context of a call.
+func grpcConnContext(ctx context.Context) context.Context {
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(grpcConnInfo); ok {
+			return ConnContext(ctx, info.conn)
+		}
+	}
+
+	return ctx
+}
+
+func (grpcConnCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	level := credentials.NoSecurity
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		if err := tlsConn.Handshake(); err != nil {
+			return nil, nil, err
+		}
+
+		level = credentials.PrivacyAndIntegrity
+	}
+
+	return conn, grpcConnInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: level},
+		conn:           conn,
+	}, nil
+}
+
+func (grpcConnCredentials) ClientHandshake(_ context.Context, _ string, _ net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("The connection credentials can only be used by the server.")
+}
+
+func (grpcConnCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "insecure"}
+}
+
+func (c grpcConnCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (grpcConnCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+func (grpcConnInfo) AuthType() string {
+	return "conn"
+}
+
+func (s *grpcConnStream) Context() context.Context {
+	return s.ctx
+}
+
+// grpcCall dispatches the request, and decodes the operation's response into out.
+// If field is set, the response is decoded into that field of out.
+func grpcCall[O proto.Message](ctx context.Context, g *GRPCServer, req dispatchRequest, field string, out O) (O, error) {
+	if _, ok := g.calls.Operations()[req.OperationID]; !ok {
+		return out, status.Errorf(codes.Unimplemented, "Operation '%s' is not available", req.OperationID)
+	}
+
+	resp, err := g.calls.Call(ctx, grpcHeader(ctx), req)
+	if err != nil {
+		return out, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if resp.Status >= http.StatusBadRequest {
+		return out, grpcError(resp)
+	}
+
+	data := resp.Data
+	if len(data) == 0 {
+		return out, nil
+	}
+
+	if field != "" {
+		if data, err = json.Marshal(map[string]json.RawMessage{field: data}); err != nil {
+			return out, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, out); err != nil {
+		return out, status.Errorf(codes.Internal, "Cannot decode response: %v", err)
+	}
+
+	return out, nil
+}
+
+// grpcHeader returns the forwarded request metadata as headers.
+func grpcHeader(ctx context.Context) http.Header {
+	header := http.Header{}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, key := range grpcForwardedMetadata {
+			if values := md.Get(key); len(values) > 0 {
+				header[http.CanonicalHeaderKey(key)] = values
+			}
+		}
+	}
+
+	return header
+}
+
+// grpcError converts an unsuccessful operation response to a gRPC status.
+func grpcError(resp dispatchResponse) error {
+	code := codes.Unknown
+	switch resp.Status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		code = codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	case http.StatusGatewayTimeout:
+		code = codes.DeadlineExceeded
+	default:
+		if resp.Status >= http.StatusInternalServerError {
+			code = codes.Internal
+		}
+	}
+
+	return status.Error(code, resp.ErrorMessage())
+}
+
+// grpcValue converts event data to a protobuf value, with the same structure as its JSON representation.
+func grpcValue(data any) (*structpb.Value, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	value := &structpb.Value{}
+	if err := protojson.Unmarshal(b, value); err != nil {
+		return nil, fmt.Errorf("Cannot convert event data: %w", err)
+	}
+
+	return value, nil
+}
+
+func addressRequest(operationID string, req *rpc.AddressRequest) dispatchRequest {
+	return dispatchRequest{
+		OperationID: operationID,
+		Params:      map[string]any{"address": req.GetAddress()},
+	}
+}
+
+func authRuleRequest(operationID, ruleID string) dispatchRequest {
+	return dispatchRequest{
+		OperationID: operationID,
+		Params:      map[string]any{"rule_id": ruleID},
+	}
+}
@@ -0,0 +1,135 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ac "github.com/bluetuith-org/api-native/api/appcapability"
+	"github.com/bluetuith-org/daemon/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// newTestCertificate returns a certificate for the common name, which is signed by the parent,
+// or is self-signed if parent is nil.
+func newTestCertificate(t *testing.T, name string, parent *tls.Certificate) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+
+	signer, signerKey := template, any(key)
+	if parent != nil {
+		signer, signerKey = parent.Leaf, parent.PrivateKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func TestGRPCServerTLS(t *testing.T) {
+	ca := newTestCertificate(t, "ca", nil)
+	server := newTestCertificate(t, "127.0.0.1", &ca)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Leaf)
+
+	certs, err := NewCertPolicy([]string{"read=kiosk"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokens, err := OpenTokenStore(filepath.Join(t.TempDir(), "tokens.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grpcServer := NewGRPCServer(tls.NewListener(listener, &tls.Config{
+		Certificates: []tls.Certificate{server},
+		ClientCAs:    pool,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		NextProtos:   []string{"h2"},
+	}))
+	t.Cleanup(grpcServer.Close)
+
+	router := http.NewServeMux()
+	api, _ := register(router, nil, ac.MergedCollection(), Options{Tokens: tokens, Certs: certs}, false)
+	grpcServer.start(newEventHub(0), newDispatcher(api, router, newAccessControl(tokens, nil, certs)))
+
+	tests := []struct {
+		name string
+		cert string
+		want codes.Code
+	}{
+		{name: "allowed certificate", cert: "kiosk", want: codes.OK},
+		{name: "other certificate", cert: "guest", want: codes.PermissionDenied},
+		{name: "no certificate", want: codes.Unauthenticated},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := &tls.Config{RootCAs: pool}
+			if test.cert != "" {
+				config.Certificates = []tls.Certificate{newTestCertificate(t, test.cert, &ca)}
+			}
+
+			conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(config)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_, err = rpc.NewBluerestdClient(conn).Info(ctx, &emptypb.Empty{})
+			if code := status.Code(err); code != test.want {
+				t.Errorf("Info returned %v (%v), want %v", code, err, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	ac "github.com/bluetuith-org/api-native/api/appcapability"
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// readinessTimeout is the duration to wait for the session to list its adapters.
+const readinessTimeout = 5 * time.Second
+
+// DaemonInfo describes the daemon and the platform that the Bluetooth session runs on.
+type DaemonInfo struct {
+	Version  string `json:"version" doc:"The version of the daemon."`
+	Revision string `json:"revision" doc:"The revision of the daemon."`
+	Stack    string `json:"stack" doc:"The Bluetooth stack of the platform."`
+	OS       string `json:"os" doc:"The operating system of the platform."`
+}
+
+// capabilityError describes why a capability is not available.
+type capabilityError struct {
+	Capability string `json:"capability" doc:"The name of the capability."`
+	Error      string `json:"error" doc:"The reason why the capability is not available."`
+}
+
+// readinessCheck is the result of a single readiness check.
+type readinessCheck struct {
+	Name    string `json:"name" enum:"session,adapters,stack,journal" doc:"The name of the check."`
+	OK      bool   `json:"ok" doc:"Whether the check passed."`
+	Message string `json:"message,omitempty" doc:"The reason why the check failed."`
+}
+
+// infoCapabilities are the capabilities that are reported by the '/info' endpoint.
+var infoCapabilities = []ac.Capability{
+	ac.CapabilitySendFile,
+	ac.CapabilityReceiveFile,
+	ac.CapabilityNetwork,
+	ac.CapabilityMediaPlayer,
+}
+
+func healthEndpoints(api huma.API, session bluetooth.Session, collection ac.Collection, info DaemonInfo, journal *Journal) {
+	healthzEndpoint(api)
+	readyzEndpoint(api, session, journal)
+	infoEndpoint(api, collection, info)
+}
+
+func healthzEndpoint(api huma.API) {
+	type HealthOutput struct {
+		Body struct {
+			Status string `json:"status" enum:"ok" doc:"The status of the daemon."`
+		}
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "healthz",
+		Method:      http.MethodGet,
+		Path:        "/healthz",
+		Summary:     "Health",
+		Description: "This endpoint reports that the daemon is alive, and can be used as a liveness probe.",
+		Security:    tokenScopes(""),
+		Tags:        []string{"Daemon"},
+	}, func(_ context.Context, _ *struct{}) (*HealthOutput, error) {
+		health := &HealthOutput{}
+		health.Body.Status = "ok"
+
+		return health, nil
+	})
+}
+
+func readyzEndpoint(api huma.API, session bluetooth.Session, journal *Journal) {
+	type ReadyOutput struct {
+		Status int
+		Body   struct {
+			Ready  bool             `json:"ready" doc:"Whether the daemon is ready to serve requests."`
+			Checks []readinessCheck `json:"checks" doc:"The results of each readiness check."`
+		}
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "readyz",
+		Method:      http.MethodGet,
+		Path:        "/readyz",
+		Summary:     "Readiness",
+		Description: "This endpoint reports whether the daemon is ready, that is, the Bluetooth session responds to a listing of its adapters, at least one adapter is present, the Bluetooth stack is reachable, and the journal can be written to, if it is enabled. If the daemon is not ready, the status code is `503`, and the failed checks are described in the response.",
+		Security:    tokenScopes(""),
+		Tags:        []string{"Daemon"},
+	}, func(_ context.Context, _ *struct{}) (*ReadyOutput, error) {
+		ready := &ReadyOutput{}
+		ready.Body.Checks = readinessChecks(session)
+		if journal != nil {
+			check := readinessCheck{Name: "journal", OK: true}
+			if err := journal.Err(); err != nil {
+				check.OK, check.Message = false, err.Error()
+			}
+
+			ready.Body.Checks = append(ready.Body.Checks, check)
+		}
+
+		ready.Status, ready.Body.Ready = http.StatusOK, true
+		for _, check := range ready.Body.Checks {
+			if !check.OK {
+				ready.Status, ready.Body.Ready = http.StatusServiceUnavailable, false
+				break
+			}
+		}
+
+		return ready, nil
+	})
+}
+
+func infoEndpoint(api huma.API, collection ac.Collection, info DaemonInfo) {
+	type InfoOutput struct {
+		Body struct {
+			DaemonInfo
+			StartedAt    time.Time         `json:"started_at" doc:"The time at which the daemon was started."`
+			Capabilities []string          `json:"capabilities" doc:"The capabilities that are available on the platform."`
+			Unavailable  []capabilityError `json:"unavailable" doc:"The capabilities that are not available, along with the reasons."`
+		}
+	}
+
+	started := time.Now()
+
+	huma.Register(api, huma.Operation{
+		OperationID: "info",
+		Method:      http.MethodGet,
+		Path:        "/info",
+		Summary:     "Information",
+		Description: "This endpoint fetches information about the daemon and its platform, like the version, the Bluetooth stack, and the available capabilities. For each capability that is not available, the reason is provided.",
+		Security:    tokenScopes(ScopeRead),
+		Tags:        []string{"Daemon"},
+	}, func(_ context.Context, _ *struct{}) (*InfoOutput, error) {
+		output := &InfoOutput{}
+		output.Body.DaemonInfo = info
+		output.Body.StartedAt = started
+		output.Body.Capabilities = []string{}
+		output.Body.Unavailable = []capabilityError{}
+
+		for _, capability := range infoCapabilities {
+			if collection.Has(capability) {
+				output.Body.Capabilities = append(output.Body.Capabilities, capability.String())
+			}
+		}
+
+		if errs, ok := collection.Errors.Exists(); ok {
+			for capability, err := range errs {
+				output.Body.Unavailable = append(output.Body.Unavailable, capabilityError{
+					Capability: capability.String(),
+					Error:      err.Err.Error(),
+				})
+			}
+
+			slices.SortFunc(output.Body.Unavailable, func(a, b capabilityError) int {
+				return strings.Compare(a.Capability, b.Capability)
+			})
+		}
+
+		return output, nil
+	})
+}
+
+// readinessChecks checks whether the session responds to a listing of its adapters, whether
+// any adapters are present, and whether the Bluetooth stack responds to calls for the adapters.
+func readinessChecks(session bluetooth.Session) []readinessCheck {
+	checks := []readinessCheck{
+		{Name: "session"},
+		{Name: "adapters"},
+		{Name: "stack"},
+	}
+
+	adapters, err := probeAdapters(session)
+	if err != nil {
+		checks[0].Message = err.Error()
+		checks[1].Message = checks[0].Message
+		checks[2].Message = checks[0].Message
+
+		return checks
+	}
+	checks[0].OK = true
+
+	if len(adapters) == 0 {
+		checks[1].Message = "No adapters are present."
+		checks[2].Message = checks[1].Message
+
+		return checks
+	}
+	checks[1].OK = true
+
+	for _, adapter := range adapters {
+		if _, err := session.Adapter(adapter.Address).Properties(); err != nil {
+			checks[2].Message = err.Error()
+			return checks
+		}
+	}
+	checks[2].OK = true
+
+	return checks
+}
+
+// probeAdapters lists the adapters of the session. It fails if the session does not
+// respond within the readiness timeout, or if it panics since it has stopped.
+func probeAdapters(session bluetooth.Session) ([]bluetooth.AdapterData, error) {
+	type result struct {
+		adapters []bluetooth.AdapterData
+		err      error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- result{err: fmt.Errorf("The Bluetooth session cannot list its adapters: %v", r)}
+			}
+		}()
+
+		done <- result{adapters: session.Adapters()}
+	}()
+
+	select {
+	case r := <-done:
+		return r.adapters, r.err
+
+	case <-time.After(readinessTimeout):
+		return nil, errors.New("The Bluetooth session did not respond in time.")
+	}
+}
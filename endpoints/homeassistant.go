@@ -0,0 +1,207 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	ac "github.com/bluetuith-org/api-native/api/appcapability"
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/puzpuzpuz/xsync/v3"
+)
+
+// DefaultHomeAssistantPrefix is the default Home Assistant MQTT discovery prefix.
+const DefaultHomeAssistantPrefix = "homeassistant"
+
+// homeAssistant publishes Home Assistant MQTT discovery configurations for paired devices.
+// Each device gets a 'connected' binary sensor, a connect/disconnect switch, a battery
+// sensor if the device reports its battery percentage, and a media player if the device
+// has one. The media player requires the MQTT media player integration in Home Assistant.
+//
+// The configurations are retained while the device is paired, even if the device is
+// disconnected, and are removed once the device is unpaired or removed.
+type homeAssistant struct {
+	prefix     string
+	collection ac.Collection
+
+	// configs maps device addresses to the configuration topics published for them.
+	configs *xsync.MapOf[string, []string]
+}
+
+// homeAssistantState is the state of a device, as published for Home Assistant.
+type homeAssistantState struct {
+	Connected bool `json:"connected"`
+	Battery   *int `json:"battery,omitempty"`
+}
+
+type homeAssistantDevice struct {
+	Identifiers []string    `json:"identifiers"`
+	Connections [][2]string `json:"connections,omitempty"`
+	Name        string      `json:"name,omitempty"`
+}
+
+type homeAssistantConfig struct {
+	Name                string              `json:"name"`
+	UniqueID            string              `json:"unique_id"`
+	ObjectID            string              `json:"object_id"`
+	Device              homeAssistantDevice `json:"device"`
+	AvailabilityTopic   string              `json:"availability_topic"`
+	PayloadAvailable    string              `json:"payload_available"`
+	PayloadNotAvailable string              `json:"payload_not_available"`
+	StateTopic          string              `json:"state_topic,omitempty"`
+	ValueTemplate       string              `json:"value_template,omitempty"`
+	CommandTopic        string              `json:"command_topic,omitempty"`
+	PayloadOn           string              `json:"payload_on,omitempty"`
+	PayloadOff          string              `json:"payload_off,omitempty"`
+	DeviceClass         string              `json:"device_class,omitempty"`
+	UnitOfMeasurement   string              `json:"unit_of_measurement,omitempty"`
+	StateClass          string              `json:"state_class,omitempty"`
+	Icon                string              `json:"icon,omitempty"`
+
+	CommandPlayTopic       string `json:"command_play_topic,omitempty"`
+	CommandPlayPayload     string `json:"command_play_payload,omitempty"`
+	CommandPauseTopic      string `json:"command_pause_topic,omitempty"`
+	CommandPausePayload    string `json:"command_pause_payload,omitempty"`
+	CommandNextTopic       string `json:"command_next_topic,omitempty"`
+	CommandNextPayload     string `json:"command_next_payload,omitempty"`
+	CommandPreviousTopic   string `json:"command_previous_topic,omitempty"`
+	CommandPreviousPayload string `json:"command_previous_payload,omitempty"`
+}
+
+func newHomeAssistant(prefix string, collection ac.Collection) *homeAssistant {
+	if prefix == "" {
+		prefix = DefaultHomeAssistantPrefix
+	}
+
+	return &homeAssistant{
+		prefix:     strings.TrimSuffix(prefix, "/"),
+		collection: collection,
+		configs:    xsync.NewMapOf[string, []string](),
+	}
+}
+
+// subscribe republishes all discovery configurations when Home Assistant comes online.
+func (h *homeAssistant) subscribe(m *MQTTBridge) {
+	m.subscribe(h.prefix+"/status", func(_ mqtt.Client, msg mqtt.Message) {
+		if string(msg.Payload()) == mqttOnline {
+			go m.publishAllStates()
+		}
+	})
+}
+
+// publishDevice publishes the discovery configurations and state of a device.
+// If the device is not paired, its configurations are removed.
+func (h *homeAssistant) publishDevice(m *MQTTBridge, adapter string, address bluetooth.MacAddress, properties bluetooth.DeviceData) {
+	if !properties.Paired {
+		h.removeDevice(m, address)
+		return
+	}
+
+	mac := address.String()
+	id := "bluerestd_" + strings.ToLower(strings.ReplaceAll(mac, ":", ""))
+	name := properties.Name
+	if name == "" {
+		name = mac
+	}
+
+	state := homeAssistantState{Connected: properties.Connected}
+	if properties.Percentage > 0 {
+		state.Battery = &properties.Percentage
+	}
+
+	device := homeAssistantDevice{
+		Identifiers: []string{id},
+		Connections: [][2]string{{"bluetooth", mac}},
+		Name:        name,
+	}
+
+	stateTopic := m.topic(adapter, mac, "homeassistant")
+	entity := func(component, object, entityName string) (string, homeAssistantConfig) {
+		return h.prefix + "/" + component + "/" + id + "/" + object + "/config", homeAssistantConfig{
+			Name:                entityName,
+			UniqueID:            id + "_" + object,
+			ObjectID:            id + "_" + object,
+			Device:              device,
+			AvailabilityTopic:   m.topic("status"),
+			PayloadAvailable:    mqttOnline,
+			PayloadNotAvailable: mqttOffline,
+		}
+	}
+
+	configs := map[string]homeAssistantConfig{}
+
+	topic, config := entity("binary_sensor", "connected", "Connected")
+	config.StateTopic = stateTopic
+	config.ValueTemplate = "{{ 'ON' if value_json.connected else 'OFF' }}"
+	config.DeviceClass = "connectivity"
+	configs[topic] = config
+
+	topic, config = entity("switch", "connection", "Connection")
+	config.StateTopic = stateTopic
+	config.ValueTemplate = "{{ 'connect' if value_json.connected else 'disconnect' }}"
+	config.CommandTopic = m.topic(adapter, mac, "command")
+	config.PayloadOn = "connect"
+	config.PayloadOff = "disconnect"
+	config.Icon = "mdi:bluetooth-connect"
+	configs[topic] = config
+
+	if state.Battery != nil {
+		topic, config = entity("sensor", "battery", "Battery")
+		config.StateTopic = stateTopic
+		config.ValueTemplate = "{{ value_json.battery }}"
+		config.DeviceClass = "battery"
+		config.UnitOfMeasurement = "%"
+		config.StateClass = "measurement"
+		configs[topic] = config
+	}
+
+	// The media player of a device can only be found while it is connected,
+	// so it is published once it has been found, and retained afterwards.
+	if h.collection.Has(ac.CapabilityMediaPlayer) && state.Connected {
+		if _, err := m.session.MediaPlayer(address).Properties(); err == nil {
+			commandTopic := m.topic(adapter, mac, "command")
+
+			topic, config = entity("media_player", "media", "Media")
+			config.CommandPlayTopic, config.CommandPlayPayload = commandTopic, "play"
+			config.CommandPauseTopic, config.CommandPausePayload = commandTopic, "pause"
+			config.CommandNextTopic, config.CommandNextPayload = commandTopic, "next"
+			config.CommandPreviousTopic, config.CommandPreviousPayload = commandTopic, "previous"
+			config.Icon = "mdi:multimedia"
+			configs[topic] = config
+		}
+	}
+
+	for topic, config := range configs {
+		payload, err := json.Marshal(config)
+		if err != nil {
+			continue
+		}
+
+		m.publish(topic, true, payload)
+	}
+
+	published, _ := h.configs.Load(mac)
+	for topic := range configs {
+		if !slices.Contains(published, topic) {
+			published = append(published, topic)
+		}
+	}
+	h.configs.Store(mac, published)
+
+	if payload, err := json.Marshal(state); err == nil {
+		m.publish(stateTopic, true, payload)
+	}
+}
+
+// removeDevice removes all discovery configurations published for a device.
+func (h *homeAssistant) removeDevice(m *MQTTBridge, address bluetooth.MacAddress) {
+	topics, ok := h.configs.LoadAndDelete(address.String())
+	if !ok {
+		return
+	}
+
+	for _, topic := range topics {
+		m.publish(topic, true, []byte{})
+	}
+}
@@ -0,0 +1,239 @@
+package endpoints
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/puzpuzpuz/xsync/v3"
+)
+
+const (
+	// eventQueueSize is the number of events that can be queued for
+	// a single subscriber before newer events are dropped.
+	eventQueueSize = 128
+
+	// DefaultEventBufferSize is the default number of recent events that are kept for replay.
+	DefaultEventBufferSize = 256
+)
+
+type hubEvent struct {
+	ID   uint
+	Name string
+	Data any
+}
+
+// eventGap is sent to a subscriber in place of events that it has missed,
+// either because they are no longer buffered or because its queue was full.
+type eventGap struct {
+	LastEventID uint   `json:"last_event_id" doc:"The ID of the last event that was received by the client."`
+	Message     string "json:\"message\" doc:\"A description of the gap. When received, resynchronize the current state using the `/adapters` and `/adapter/{address}/devices` endpoints.\""
+}
+
+type eventSubscriber struct {
+	id       int64
+	events   chan hubEvent
+	replay   []hubEvent
+	last     uint
+	next     uint
+	listener bool
+}
+
+type eventHub struct {
+	id          atomic.Int64
+	subscribers *xsync.MapOf[int64, *eventSubscriber]
+
+	mu     sync.Mutex
+	epoch  uint
+	seq    uint
+	buffer *eventRing
+}
+
+// eventRing is a fixed-size ring buffer of the most recently published events.
+type eventRing struct {
+	events []hubEvent
+	start  int
+	size   int
+}
+
+// newEventHub returns an event hub, which numbers the events after the epoch of the current run.
+// The epoch is the start time in microseconds, so that the event IDs of a run are larger than
+// the IDs of all previous runs, and IDs from a previous run can be detected when replaying events.
+func newEventHub(bufferSize int) *eventHub {
+	epoch := uint(time.Now().UnixMicro())
+
+	return &eventHub{
+		subscribers: xsync.NewMapOf[int64, *eventSubscriber](),
+		epoch:       epoch,
+		seq:         epoch,
+		buffer:      newEventRing(bufferSize),
+	}
+}
+
+// Publish is called by the eventbus for every published event, and fans out
+// the event to all subscribers. Subscribers whose queues are full will miss the event.
+func (h *eventHub) Publish(_ uint, name string, data any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.seq++
+	event := hubEvent{ID: h.seq, Name: name, Data: data}
+	h.buffer.Push(event)
+
+	h.subscribers.Range(func(_ int64, sub *eventSubscriber) bool {
+		// If the queue is full, the subscriber misses the event, and
+		// receives a gap marker before the next event instead.
+		select {
+		case sub.events <- event:
+		default:
+		}
+
+		return true
+	})
+}
+
+// Subscribe adds a new subscriber to the hub. If lastEventID is non-zero, all buffered
+// events after it are queued for replay, with a gap marker if some of them were not buffered,
+// or if the ID is not from the current run of the daemon.
+func (h *eventHub) Subscribe(lastEventID uint) *eventSubscriber {
+	return h.subscribe(lastEventID, false)
+}
+
+func (h *eventHub) subscribe(lastEventID uint, listener bool) *eventSubscriber {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &eventSubscriber{
+		id:       h.id.Add(1),
+		events:   make(chan hubEvent, eventQueueSize),
+		last:     lastEventID,
+		next:     h.seq + 1,
+		listener: listener,
+	}
+
+	if lastEventID > 0 {
+		// An ID from a previous run is older than the epoch, and all events of
+		// the current run have been missed.
+		missed, complete := h.buffer.Since(max(lastEventID, h.epoch), h.seq)
+		if !complete || lastEventID < h.epoch {
+			sub.replay = append(sub.replay, sub.gap())
+		}
+
+		sub.replay = append(sub.replay, missed...)
+	}
+
+	h.subscribers.Store(sub.id, sub)
+
+	return sub
+}
+
+// Listen calls handler in the background for every subsequently published event,
+// preceded by a gap marker if any events were missed. Listeners are used by the
+// internal consumers of events, and are not counted as subscribers.
+func (h *eventHub) Listen(handler func(event hubEvent)) {
+	sub := h.subscribe(0, true)
+
+	go func() {
+		for queued := range sub.events {
+			for _, event := range sub.Next(queued) {
+				handler(event)
+			}
+		}
+	}()
+}
+
+func (h *eventHub) Unsubscribe(sub *eventSubscriber) {
+	h.subscribers.Delete(sub.id)
+}
+
+// Subscribers returns the number of clients that are subscribed to events.
+func (h *eventHub) Subscribers() int {
+	var count int
+	h.subscribers.Range(func(_ int64, sub *eventSubscriber) bool {
+		if !sub.listener {
+			count++
+		}
+
+		return true
+	})
+
+	return count
+}
+
+// Next returns the events that should be sent to the client after receiving
+// an event from the queue, which is preceded by a gap marker if any events were dropped
+// before it. Since the IDs are sequential, events were dropped if the ID is not the next one.
+func (s *eventSubscriber) Next(event hubEvent) []hubEvent {
+	var events []hubEvent
+
+	if event.ID != s.next {
+		events = append(events, s.gap())
+	}
+
+	s.last, s.next = event.ID, event.ID+1
+
+	return append(events, event)
+}
+
+// Replay returns the events queued for replay, and clears the replay queue.
+func (s *eventSubscriber) Replay() []hubEvent {
+	replay := s.replay
+	s.replay = nil
+
+	if len(replay) > 0 {
+		s.last = replay[len(replay)-1].ID
+	}
+
+	return replay
+}
+
+func (s *eventSubscriber) gap() hubEvent {
+	return hubEvent{
+		Name: "gap",
+		Data: eventGap{
+			LastEventID: s.last,
+			Message:     "Some events were missed, the current state should be resynchronized.",
+		},
+	}
+}
+
+func newEventRing(size int) *eventRing {
+	size = max(size, 0)
+
+	return &eventRing{events: make([]hubEvent, size)}
+}
+
+// Push adds an event to the ring, overwriting the oldest event if the ring is full.
+func (r *eventRing) Push(event hubEvent) {
+	if len(r.events) == 0 {
+		return
+	}
+
+	if r.size < len(r.events) {
+		r.events[(r.start+r.size)%len(r.events)] = event
+		r.size++
+
+		return
+	}
+
+	r.events[r.start] = event
+	r.start = (r.start + 1) % len(r.events)
+}
+
+// Since returns all buffered events after the provided event ID, and whether
+// every event after the ID up to the latest event ID (seq) is buffered.
+func (r *eventRing) Since(id, seq uint) ([]hubEvent, bool) {
+	if id >= seq {
+		return nil, id == seq
+	}
+
+	var events []hubEvent
+	for i := range r.size {
+		event := r.events[(r.start+i)%len(r.events)]
+		if event.ID > id {
+			events = append(events, event)
+		}
+	}
+
+	return events, len(events) > 0 && events[0].ID == id+1
+}
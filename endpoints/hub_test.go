@@ -0,0 +1,187 @@
+package endpoints
+
+import (
+	"slices"
+	"testing"
+)
+
+// eventIDs returns the IDs of the events, with 0 for gap markers.
+func eventIDs(events []hubEvent) []uint {
+	ids := make([]uint, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+
+	return ids
+}
+
+func TestEventRingSince(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		pushed   uint
+		since    uint
+		want     []uint
+		complete bool
+	}{
+		{name: "all buffered", size: 4, pushed: 3, since: 1, want: []uint{2, 3}, complete: true},
+		{name: "latest event", size: 4, pushed: 3, since: 3, want: nil, complete: true},
+		{name: "after latest event", size: 4, pushed: 3, since: 5, want: nil, complete: false},
+		{name: "overwritten", size: 3, pushed: 6, since: 1, want: []uint{4, 5, 6}, complete: false},
+		{name: "oldest buffered", size: 3, pushed: 6, since: 3, want: []uint{4, 5, 6}, complete: true},
+		{name: "no buffer", size: 0, pushed: 3, since: 1, want: nil, complete: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ring := newEventRing(test.size)
+			for id := uint(1); id <= test.pushed; id++ {
+				ring.Push(hubEvent{ID: id})
+			}
+
+			events, complete := ring.Since(test.since, test.pushed)
+			if got := eventIDs(events); !slices.Equal(got, test.want) {
+				t.Errorf("Since(%d) returned events %v, want %v", test.since, got, test.want)
+			}
+			if complete != test.complete {
+				t.Errorf("Since(%d) returned complete %v, want %v", test.since, complete, test.complete)
+			}
+		})
+	}
+}
+
+func TestEventHubReplay(t *testing.T) {
+	const bufferSize = 4
+
+	hub := newEventHub(bufferSize)
+	epoch := hub.epoch
+
+	for range 6 {
+		hub.Publish(0, "device", nil)
+	}
+
+	// The events of the current run are numbered epoch+1 to epoch+6, and only the last 4 are buffered.
+	tests := []struct {
+		name        string
+		lastEventID uint
+		gap         bool
+		want        []uint
+	}{
+		{name: "no ID", lastEventID: 0, gap: false, want: nil},
+		{name: "buffered", lastEventID: epoch + 3, gap: false, want: []uint{epoch + 4, epoch + 5, epoch + 6}},
+		{name: "latest", lastEventID: epoch + 6, gap: false, want: nil},
+		{name: "no longer buffered", lastEventID: epoch + 1, gap: true, want: []uint{epoch + 3, epoch + 4, epoch + 5, epoch + 6}},
+		{name: "previous run", lastEventID: 3, gap: true, want: []uint{epoch + 3, epoch + 4, epoch + 5, epoch + 6}},
+		{name: "unknown", lastEventID: epoch + 100, gap: true, want: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sub := hub.Subscribe(test.lastEventID)
+			defer hub.Unsubscribe(sub)
+
+			replay := sub.Replay()
+			if gap := len(replay) > 0 && replay[0].Name == "gap"; gap != test.gap {
+				t.Fatalf("Replay starts with a gap: %v, want %v", gap, test.gap)
+			}
+			if test.gap {
+				if data := replay[0].Data.(eventGap); data.LastEventID != test.lastEventID {
+					t.Errorf("Gap has last event ID %d, want %d", data.LastEventID, test.lastEventID)
+				}
+
+				replay = replay[1:]
+			}
+
+			if got := eventIDs(replay); !slices.Equal(got, test.want) && len(got)+len(test.want) > 0 {
+				t.Errorf("Replayed events %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestEventHubFanOut(t *testing.T) {
+	hub := newEventHub(0)
+
+	subs := []*eventSubscriber{hub.Subscribe(0), hub.Subscribe(0), hub.Subscribe(0)}
+	hub.Unsubscribe(subs[2])
+
+	listened := make(chan hubEvent, 1)
+	hub.Listen(func(event hubEvent) {
+		listened <- event
+	})
+
+	if got := hub.Subscribers(); got != 2 {
+		t.Errorf("Hub has %d subscribers, want 2 without the listener", got)
+	}
+
+	hub.Publish(0, "adapter", nil)
+
+	for i, sub := range subs {
+		select {
+		case event := <-sub.events:
+			if i == 2 {
+				t.Errorf("Unsubscribed subscriber received event %d", event.ID)
+			}
+			if event.Name != "adapter" {
+				t.Errorf("Subscriber %d received event '%s', want 'adapter'", i, event.Name)
+			}
+
+		default:
+			if i != 2 {
+				t.Errorf("Subscriber %d did not receive the event", i)
+			}
+		}
+	}
+
+	if event := <-listened; event.Name != "adapter" {
+		t.Errorf("Listener received event '%s', want 'adapter'", event.Name)
+	}
+}
+
+func TestEventHubOverflow(t *testing.T) {
+	tests := []struct {
+		name      string
+		published int
+		gap       bool
+	}{
+		{name: "within queue", published: eventQueueSize, gap: false},
+		{name: "queue full", published: eventQueueSize + 5, gap: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hub := newEventHub(0)
+			sub := hub.Subscribe(0)
+			first := hub.seq + 1
+
+			for range test.published {
+				hub.Publish(0, "device", nil)
+			}
+
+			var events []hubEvent
+			for range eventQueueSize {
+				events = append(events, sub.Next(<-sub.events)...)
+			}
+
+			hub.Publish(0, "device", nil)
+			next := sub.Next(<-sub.events)
+
+			if len(events) != eventQueueSize || events[0].ID != first {
+				t.Fatalf("Received %d queued events starting at %d, want %d starting at %d", len(events), events[0].ID, eventQueueSize, first)
+			}
+
+			if gap := next[0].Name == "gap"; gap != test.gap {
+				t.Fatalf("Next event is preceded by a gap: %v, want %v", gap, test.gap)
+			}
+			if test.gap {
+				if data := next[0].Data.(eventGap); data.LastEventID != events[len(events)-1].ID {
+					t.Errorf("Gap has last event ID %d, want %d", data.LastEventID, events[len(events)-1].ID)
+				}
+			}
+
+			if last := next[len(next)-1]; last.ID != hub.seq {
+				t.Errorf("Next event has ID %d, want %d", last.ID, hub.seq)
+			}
+		})
+	}
+}
@@ -0,0 +1,410 @@
+package endpoints
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+const (
+	// DefaultJournalMaxSize is the default maximum size of a journal file (in bytes), before it is rotated.
+	DefaultJournalMaxSize = 10 * 1024 * 1024
+
+	// DefaultJournalMaxFiles is the default number of rotated journal files that are kept.
+	DefaultJournalMaxFiles = 5
+
+	journalFileName = "journal.jsonl"
+	journalMaxLimit = 1000
+)
+
+// Journal records all events and mutating API calls to an append-only file, which is
+// rotated once it reaches its maximum size. Rotated files are suffixed with a number,
+// with the highest numbered file being the oldest one.
+type Journal struct {
+	dir      string
+	maxSize  int64
+	maxFiles int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+
+	// err is the error of the last failed write, which is cleared once an entry is recorded again.
+	err error
+}
+
+// journalEntry is a single record in the journal.
+type journalEntry struct {
+	Time      time.Time       `json:"time" doc:"The time at which the entry was recorded."`
+	Type      string          "json:\"type\" doc:\"The event name for events, or `call` for API calls.\""
+	EventID   uint            `json:"event_id,omitempty" doc:"The ID of the event."`
+	Action    string          `json:"action,omitempty" doc:"The action of the event."`
+	Addresses []string        `json:"addresses,omitempty" doc:"The device or adapter addresses associated with the entry."`
+	Data      json.RawMessage `json:"data,omitempty" doc:"The data of the event."`
+
+	Operation string  `json:"operation,omitempty" doc:"The operation ID of the API call."`
+	Method    string  `json:"method,omitempty" doc:"The HTTP method of the API call."`
+	Path      string  `json:"path,omitempty" doc:"The path and query of the API call."`
+	Status    int     `json:"status,omitempty" doc:"The HTTP status code of the API call's response."`
+	Client    string  `json:"client,omitempty" doc:"The remote address of the API client."`
+	Caller    *Caller `json:"caller,omitempty" doc:"The peer credentials of the API client, if it is connected to a UNIX socket."`
+	Cert      string  `json:"cert,omitempty" doc:"The common name of the API client's certificate, if it has presented a verified client certificate."`
+}
+
+// journalQuery describes the criteria to select journal entries with.
+type journalQuery struct {
+	Since     time.Time
+	Until     time.Time
+	Types     []string
+	Addresses []string
+	Limit     int
+}
+
+// NewJournal opens the journal in the provided directory, which is created if it does not exist.
+func NewJournal(dir string, maxSize int64, maxFiles int) (*Journal, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("Cannot create journal directory: %w", err)
+	}
+
+	j := &Journal{
+		dir:      dir,
+		maxSize:  max(maxSize, 1024),
+		maxFiles: max(maxFiles, 0),
+	}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// Close closes the journal.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+
+	return j.file.Close()
+}
+
+// Err returns the error of the last failed write, if entries cannot be recorded.
+func (j *Journal) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.err
+}
+
+// start records all events from the hub.
+func (j *Journal) start(hub *eventHub) {
+	hub.Listen(j.recordEvent)
+}
+
+// middleware records all mutating API calls.
+func (j *Journal) middleware(ctx huma.Context, next func(huma.Context)) {
+	next(ctx)
+
+	if !isMutating(ctx) {
+		return
+	}
+
+	u := ctx.URL()
+	if query := u.Query(); query.Has(tokenQueryParam) {
+		query.Del(tokenQueryParam)
+		u.RawQuery = query.Encode()
+	}
+
+	entry := journalEntry{
+		Time:      time.Now(),
+		Type:      "call",
+		Operation: ctx.Operation().OperationID,
+		Method:    ctx.Method(),
+		Path:      u.RequestURI(),
+		Status:    ctx.Status(),
+		Client:    ctx.RemoteAddr(),
+	}
+	if mac, err := bluetooth.ParseMAC(ctx.Param("address")); err == nil {
+		entry.Addresses = []string{mac.String()}
+	}
+	if caller, ok := CallerFromContext(ctx.Context()); ok {
+		entry.Caller = &caller
+	}
+	if name, ok := ClientCertificateFromContext(ctx.Context()); ok {
+		entry.Cert = name
+	}
+
+	j.record(entry)
+}
+
+func (j *Journal) recordEvent(event hubEvent) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return
+	}
+
+	props := event.Properties()
+	j.record(journalEntry{
+		Time:      time.Now(),
+		Type:      event.Name,
+		EventID:   event.ID,
+		Action:    props.action,
+		Addresses: props.addresses,
+		Data:      data,
+	})
+}
+
+func (j *Journal) record(entry journalEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	err = j.write(line)
+	switch {
+	case err != nil && j.err == nil:
+		slog.Error("Cannot record journal entries", "dir", j.dir, "error", err)
+
+	case err == nil && j.err != nil:
+		slog.Info("Recording journal entries again", "dir", j.dir)
+	}
+
+	j.err = err
+}
+
+// write appends the line to the current file, which is rotated if it would exceed its
+// maximum size. If the file could not be reopened after a failed rotation or write, it is
+// reopened first. It must be called with the lock held.
+func (j *Journal) write(line []byte) error {
+	if j.file == nil {
+		if err := j.open(); err != nil {
+			return err
+		}
+	}
+
+	if j.size+int64(len(line)) > j.maxSize && j.size > 0 {
+		if err := j.rotate(); err != nil {
+			return fmt.Errorf("Cannot rotate journal: %w", err)
+		}
+	}
+
+	n, err := j.file.Write(line)
+	j.size += int64(n)
+	if err != nil {
+		// The file is reopened on the next write, in case it was removed or replaced.
+		j.file.Close()
+		j.file = nil
+
+		return fmt.Errorf("Cannot write to journal: %w", err)
+	}
+
+	return nil
+}
+
+// Query returns the newest entries that match the query, in chronological order.
+// The journal files are scanned from a snapshot, so that recording is not blocked by the query.
+func (j *Journal) Query(query journalQuery) ([]journalEntry, error) {
+	if query.Limit <= 0 {
+		query.Limit = journalMaxLimit
+	}
+
+	readers, closeFiles, err := j.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer closeFiles()
+
+	// The matching entries are kept in a ring, which holds the newest ones once all files are scanned.
+	var matched int
+	ring := make([]journalEntry, query.Limit)
+
+	for _, reader := range readers {
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(nil, int(j.maxSize))
+		for scanner.Scan() {
+			var entry journalEntry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				continue
+			}
+
+			if !query.Match(entry) {
+				continue
+			}
+
+			ring[matched%query.Limit] = entry
+			matched++
+		}
+	}
+
+	if matched <= query.Limit {
+		return ring[:matched], nil
+	}
+
+	start := matched % query.Limit
+	return append(ring[start:], ring[:start]...), nil
+}
+
+// snapshot opens all journal files, from the oldest to the newest one. The current file is
+// only read up to its current size, so that entries which are recorded afterwards are not read.
+// The files remain readable if they are rotated during the query.
+func (j *Journal) snapshot() ([]io.Reader, func(), error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var files []*os.File
+	closeFiles := func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}
+
+	readers := make([]io.Reader, 0, j.maxFiles+1)
+	for i := j.maxFiles; i >= 0; i-- {
+		file, err := os.Open(j.path(i))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			closeFiles()
+			return nil, nil, err
+		}
+		files = append(files, file)
+
+		var reader io.Reader = file
+		if i == 0 {
+			reader = io.LimitReader(file, j.size)
+		}
+		readers = append(readers, reader)
+	}
+
+	return readers, closeFiles, nil
+}
+
+func (j *Journal) open() error {
+	file, err := os.OpenFile(j.path(0), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("Cannot open journal: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("Cannot open journal: %w", err)
+	}
+
+	j.file, j.size = file, info.Size()
+
+	return nil
+}
+
+// rotate shifts all journal files by one, removing the oldest one.
+// rotate closes the current file, and renames all files to the next number before opening a new
+// current file. If the new file cannot be opened, it is reopened on the next write.
+func (j *Journal) rotate() error {
+	j.file.Close()
+	j.file = nil
+
+	os.Remove(j.path(j.maxFiles))
+	for i := j.maxFiles - 1; i >= 0; i-- {
+		os.Rename(j.path(i), j.path(i+1))
+	}
+
+	return j.open()
+}
+
+func (j *Journal) path(index int) string {
+	path := filepath.Join(j.dir, journalFileName)
+	if index > 0 {
+		path += "." + strconv.Itoa(index)
+	}
+
+	return path
+}
+
+// Match reports whether the entry matches the query.
+func (q journalQuery) Match(entry journalEntry) bool {
+	if !q.Since.IsZero() && entry.Time.Before(q.Since) {
+		return false
+	}
+
+	if !q.Until.IsZero() && entry.Time.After(q.Until) {
+		return false
+	}
+
+	if len(q.Types) > 0 && !slices.Contains(q.Types, entry.Type) {
+		return false
+	}
+
+	if len(q.Addresses) > 0 {
+		return slices.ContainsFunc(entry.Addresses, func(address string) bool {
+			return slices.ContainsFunc(q.Addresses, func(a string) bool {
+				return strings.EqualFold(a, address)
+			})
+		})
+	}
+
+	return true
+}
+
+func journalEndpoint(api huma.API, journal *Journal) {
+	type JournalInput struct {
+		Since     time.Time `query:"since" doc:"Only return entries recorded at or after this time (RFC 3339)."`
+		Until     time.Time `query:"until" doc:"Only return entries recorded at or before this time (RFC 3339)."`
+		Types     []string  "query:\"type\" enum:\"call,auth,adapter,error,device,mediaplayer,filetransfer,presence,gap\" doc:\"Only return entries of the specified types. Use `call` for API calls.\""
+		Addresses []string  `query:"address" doc:"Only return entries associated with the specified device or adapter Bluetooth MAC addresses."`
+		Limit     int       `query:"limit" minimum:"1" maximum:"1000" default:"100" doc:"The maximum number of entries to return, starting from the newest one."`
+	}
+
+	type JournalOutput struct {
+		Body []journalEntry
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "journal",
+		Method:      http.MethodGet,
+		Path:        "/journal",
+		Summary:     "Journal",
+		Description: "This endpoint fetches the newest recorded events and mutating API calls from the journal, in chronological order.",
+		Security:    tokenScopes(ScopeRead),
+	}, func(_ context.Context, input *JournalInput) (*JournalOutput, error) {
+		if input.Limit <= 0 || input.Limit > journalMaxLimit {
+			input.Limit = journalMaxLimit
+		}
+
+		filter, err := newEventFilter(nil, input.Addresses, nil)
+		if err != nil {
+			return nil, huma.Error422UnprocessableEntity(err.Error())
+		}
+
+		entries, err := journal.Query(journalQuery{
+			Since:     input.Since,
+			Until:     input.Until,
+			Types:     input.Types,
+			Addresses: filter.addresses,
+			Limit:     input.Limit,
+		})
+
+		return &JournalOutput{entries}, err
+	})
+}
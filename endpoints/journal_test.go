@@ -0,0 +1,103 @@
+package endpoints
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJournalQueryNewest(t *testing.T) {
+	journal, err := NewJournal(t.TempDir(), 1024, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { journal.Close() })
+
+	// The entries span multiple rotated files.
+	started := time.Now()
+	for id := uint(1); id <= 40; id++ {
+		journal.record(journalEntry{Time: started.Add(time.Duration(id) * time.Second), Type: "device", EventID: id})
+	}
+
+	entries, err := journal.Query(journalQuery{Types: []string{"device"}, Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 5 {
+		t.Fatalf("Query returned %d entries, want 5", len(entries))
+	}
+	for i, entry := range entries {
+		if want := uint(36 + i); entry.EventID != want {
+			t.Errorf("Entry %d has event ID %d, want %d", i, entry.EventID, want)
+		}
+	}
+}
+
+func TestJournalQueryFewerThanLimit(t *testing.T) {
+	journal, err := NewJournal(t.TempDir(), 1024, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { journal.Close() })
+
+	for id := uint(1); id <= 3; id++ {
+		journal.record(journalEntry{Time: time.Now(), Type: "device", EventID: id})
+	}
+	journal.record(journalEntry{Time: time.Now(), Type: "call"})
+
+	entries, err := journal.Query(journalQuery{Types: []string{"device"}, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("Query returned %d entries, want 3", len(entries))
+	}
+	for i, entry := range entries {
+		if want := uint(i + 1); entry.EventID != want {
+			t.Errorf("Entry %d has event ID %d, want %d", i, entry.EventID, want)
+		}
+	}
+}
+
+func TestJournalRecordAfterFailedRotation(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "journal")
+
+	journal, err := NewJournal(dir, 1024, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { journal.Close() })
+
+	// The rotation fails once the directory is removed, since the new file cannot be created.
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	for id := uint(1); id <= 20; id++ {
+		journal.record(journalEntry{Time: time.Now(), Type: "device", EventID: id})
+	}
+
+	if journal.Err() == nil {
+		t.Fatal("Journal reports no error after a failed rotation")
+	}
+
+	// The file is reopened on the next entry.
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	journal.record(journalEntry{Time: time.Now(), Type: "device", EventID: 21})
+
+	if err := journal.Err(); err != nil {
+		t.Fatalf("Journal reports error %v after it was reopened", err)
+	}
+
+	entries, err := journal.Query(journalQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].EventID != 21 {
+		t.Errorf("Query returned %d entries, want only the entry recorded after reopening", len(entries))
+	}
+}
@@ -0,0 +1,492 @@
+package endpoints
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+const (
+	jsonrpcVersion       = "2.0"
+	jsonrpcMaxLineLength = 1024 * 1024
+
+	// jsonrpcMaxCalls is the maximum number of calls that are handled concurrently on a connection.
+	// Once it is reached, no further requests are read until a call is finished.
+	jsonrpcMaxCalls = 16
+
+	jsonrpcParseError     = -32700
+	jsonrpcInvalidRequest = -32600
+	jsonrpcMethodNotFound = -32601
+	jsonrpcInvalidParams  = -32602
+	jsonrpcServerError    = -32000
+)
+
+// JSONRPCServer serves a JSON-RPC 2.0 API, with one message per line, on its own listener.
+// Each operation of the REST API is exposed as a method named after its operation ID,
+// whose params are the operation's path and query parameters, with the request body
+// (if any) in the 'body' param. For example:
+//
+//	{"jsonrpc": "2.0", "id": 1, "method": "device-connect", "params": {"address": "AA:BB:CC:DD:EE:FF"}}
+//
+// The 'subscribe' method (with the optional 'type', 'address', 'action', 'last_event_id' and 'agent' params)
+// starts sending all events as 'event' notifications on the connection, with the
+// params '{"id": <id>, "event": <event-name>, "data": <data>}', until 'unsubscribe' is called.
+// If 'agent' is true, the connection answers the authorization requests while it is subscribed.
+//
+// If API tokens are used, the 'authenticate' method (with the 'token' param) sets the
+// token that is used for all subsequent calls on the connection. If the server listens
+// on a UNIX socket and a peer policy is set, clients are authorized by their peer credentials instead.
+type JSONRPCServer struct {
+	listener net.Listener
+
+	hub   *eventHub
+	calls *dispatcher
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type jsonrpcRequest struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type jsonrpcResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *jsonrpcError   `json:"error,omitempty"`
+}
+
+type jsonrpcError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+type jsonrpcNotification struct {
+	Version string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+// jsonrpcEvent holds the params of an 'event' notification.
+type jsonrpcEvent struct {
+	ID    uint   `json:"id"`
+	Event string `json:"event"`
+	Data  any    `json:"data"`
+}
+
+// jsonrpcSubscribeParams holds the params of the 'subscribe' method.
+type jsonrpcSubscribeParams struct {
+	Types       []string `json:"type"`
+	Addresses   []string `json:"address"`
+	Actions     []string `json:"action"`
+	LastEventID uint     `json:"last_event_id"`
+	Agent       bool     `json:"agent"`
+}
+
+// jsonrpcAuthenticateParams holds the params of the 'authenticate' method.
+type jsonrpcAuthenticateParams struct {
+	Token string `json:"token"`
+}
+
+// jsonrpcConn is a client connection, which has at most one active event subscription.
+type jsonrpcConn struct {
+	server *JSONRPCServer
+	conn   net.Conn
+
+	writeLock sync.Mutex
+
+	// calls holds a slot for each call that is being handled.
+	calls chan struct{}
+
+	authLock sync.Mutex
+	header   http.Header
+
+	subLock     sync.Mutex
+	unsubscribe context.CancelFunc
+}
+
+// NewJSONRPCServer returns a new JSON-RPC server. It starts serving on the listener
+// only after the API is registered.
+func NewJSONRPCServer(listener net.Listener) *JSONRPCServer {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &JSONRPCServer{
+		listener: listener,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Close stops the server, and closes all client connections.
+func (j *JSONRPCServer) Close() {
+	j.cancel()
+	j.listener.Close()
+}
+
+// start accepts connections in the background.
+func (j *JSONRPCServer) start(hub *eventHub, calls *dispatcher) {
+	j.hub, j.calls = hub, calls
+
+	go func() {
+		for {
+			conn, err := j.listener.Accept()
+			if err != nil {
+				if errors.Is(err, net.ErrClosed) {
+					return
+				}
+
+				continue
+			}
+
+			c := &jsonrpcConn{server: j, conn: conn, calls: make(chan struct{}, jsonrpcMaxCalls)}
+			go c.serve()
+		}
+	}()
+}
+
+// serve reads requests from the connection until it is closed. Each request, and each
+// request of a batch, is handled concurrently (up to jsonrpcMaxCalls requests at a time),
+// so responses may be sent out of order.
+func (c *jsonrpcConn) serve() {
+	ctx, cancel := context.WithCancel(ConnContext(c.server.ctx, c.conn))
+	defer cancel()
+
+	go func() {
+		<-ctx.Done()
+		c.conn.Close()
+	}()
+
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(nil, jsonrpcMaxLineLength)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		if line[0] != '[' {
+			var req jsonrpcRequest
+			if err := json.Unmarshal(line, &req); err != nil {
+				c.write(jsonrpcFailure(nil, jsonrpcParseError, "Parse error: "+err.Error()))
+				continue
+			}
+
+			c.acquire()
+			go func() {
+				defer c.release()
+
+				if resp := c.handle(ctx, req); resp != nil {
+					c.write(resp)
+				}
+			}()
+
+			continue
+		}
+
+		var batch []jsonrpcRequest
+		if err := json.Unmarshal(line, &batch); err != nil || len(batch) == 0 {
+			message := "Invalid request: empty batch"
+			if err != nil {
+				message = "Parse error: " + err.Error()
+			}
+
+			c.write(jsonrpcFailure(nil, jsonrpcInvalidRequest, message))
+			continue
+		}
+
+		responses := make([]*jsonrpcResponse, len(batch))
+
+		var wg sync.WaitGroup
+		for i, req := range batch {
+			c.acquire()
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer c.release()
+
+				responses[i] = c.handle(ctx, req)
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+
+			results := make([]*jsonrpcResponse, 0, len(responses))
+			for _, resp := range responses {
+				if resp != nil {
+					results = append(results, resp)
+				}
+			}
+
+			if len(results) > 0 {
+				c.write(results)
+			}
+		}()
+	}
+
+	c.stopSubscription()
+}
+
+// handle calls the requested method, and returns its response. If the request is a
+// notification (i.e. it has no ID), the method is called and no response is returned.
+func (c *jsonrpcConn) handle(ctx context.Context, req jsonrpcRequest) *jsonrpcResponse {
+	resp := c.call(ctx, req)
+	if req.ID == nil {
+		return nil
+	}
+
+	return resp
+}
+
+func (c *jsonrpcConn) call(ctx context.Context, req jsonrpcRequest) *jsonrpcResponse {
+	if req.Version != jsonrpcVersion || req.Method == "" {
+		return jsonrpcFailure(req.ID, jsonrpcInvalidRequest, "Invalid request: 'jsonrpc' must be '2.0' and 'method' must be set")
+	}
+
+	params := map[string]json.RawMessage{}
+	if len(req.Params) > 0 && !bytes.Equal(req.Params, []byte("null")) {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return jsonrpcFailure(req.ID, jsonrpcInvalidParams, "Invalid params: params must be an object")
+		}
+	}
+
+	switch req.Method {
+	case "authenticate":
+		var authenticate jsonrpcAuthenticateParams
+		if err := json.Unmarshal(req.Params, &authenticate); err != nil || authenticate.Token == "" {
+			return jsonrpcFailure(req.ID, jsonrpcInvalidParams, "Invalid params: 'token' must be set")
+		}
+
+		header := http.Header{}
+		header.Set("Authorization", "Bearer "+authenticate.Token)
+		if status, message := c.server.calls.Authorize(ctx, header, ""); status != http.StatusOK {
+			return jsonrpcUnauthorized(req.ID, status, message)
+		}
+
+		c.authLock.Lock()
+		c.header = header
+		c.authLock.Unlock()
+
+		return jsonrpcSuccess(req.ID, []byte("true"))
+
+	case "subscribe":
+		if status, message := c.server.calls.Authorize(ctx, c.authHeader(), ScopeRead); status != http.StatusOK {
+			return jsonrpcUnauthorized(req.ID, status, message)
+		}
+
+		var subscribe jsonrpcSubscribeParams
+		if err := json.Unmarshal(req.Params, &subscribe); len(req.Params) > 0 && err != nil {
+			return jsonrpcFailure(req.ID, jsonrpcInvalidParams, "Invalid params: "+err.Error())
+		}
+
+		filter, err := newEventFilter(subscribe.Types, subscribe.Addresses, subscribe.Actions)
+		if err != nil {
+			return jsonrpcFailure(req.ID, jsonrpcInvalidParams, err.Error())
+		}
+
+		c.subscribe(ctx, filter, subscribe.LastEventID, subscribe.Agent && filter.receives("auth"))
+
+		return jsonrpcSuccess(req.ID, []byte("true"))
+
+	case "unsubscribe":
+		return jsonrpcSuccess(req.ID, []byte(strconv.FormatBool(c.stopSubscription())))
+	}
+
+	if _, ok := c.server.calls.Operations()[req.Method]; !ok {
+		return jsonrpcFailure(req.ID, jsonrpcMethodNotFound, "Method not found: "+req.Method)
+	}
+
+	call := dispatchRequest{
+		OperationID: req.Method,
+		Params:      make(map[string]any, len(params)),
+		Body:        params["body"],
+	}
+	for name, raw := range params {
+		if name == "body" {
+			continue
+		}
+
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return jsonrpcFailure(req.ID, jsonrpcInvalidParams, "Invalid params: "+err.Error())
+		}
+
+		call.Params[name] = value
+	}
+
+	resp, err := c.server.calls.Call(ctx, c.authHeader(), call)
+	if err != nil {
+		return jsonrpcFailure(req.ID, jsonrpcInvalidParams, err.Error())
+	}
+
+	if resp.Status >= http.StatusBadRequest {
+		code := jsonrpcServerError
+		if resp.Status == http.StatusBadRequest || resp.Status == http.StatusUnprocessableEntity {
+			code = jsonrpcInvalidParams
+		}
+
+		failure := jsonrpcFailure(req.ID, code, resp.ErrorMessage())
+		failure.Error.Data, _ = json.Marshal(resp)
+
+		return failure
+	}
+
+	return jsonrpcSuccess(req.ID, resp.Data)
+}
+
+// subscribe replaces the connection's subscription, and sends all
+// matching events as notifications until it is stopped. If agent is set,
+// the connection is registered as an authorization agent for as long as the subscription lasts.
+func (c *jsonrpcConn) subscribe(ctx context.Context, filter eventFilter, lastEventID uint, agent bool) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	c.subLock.Lock()
+	if c.unsubscribe != nil {
+		c.unsubscribe()
+	}
+	c.unsubscribe = cancel
+	c.subLock.Unlock()
+
+	sub := c.server.hub.Subscribe(lastEventID)
+	go func() {
+		defer c.server.hub.Unsubscribe(sub)
+
+		if agent {
+			defer registerAuthAgent()()
+		}
+
+		sendEvents := func(events []hubEvent) error {
+			for _, event := range events {
+				if !filter.Match(event) {
+					continue
+				}
+
+				if err := c.write(jsonrpcNotification{
+					Version: jsonrpcVersion,
+					Method:  "event",
+					Params: jsonrpcEvent{
+						ID:    event.ID,
+						Event: event.Name,
+						Data:  event.Data,
+					},
+				}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		if err := sendEvents(sub.Replay()); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case event := <-sub.events:
+				if err := sendEvents(sub.Next(event)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// authHeader returns the headers that are sent along with each call, which hold
+// the token set by the 'authenticate' method.
+func (c *jsonrpcConn) authHeader() http.Header {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+
+	return c.header.Clone()
+}
+
+// stopSubscription stops the connection's subscription, and reports whether it was active.
+func (c *jsonrpcConn) stopSubscription() bool {
+	c.subLock.Lock()
+	defer c.subLock.Unlock()
+
+	if c.unsubscribe == nil {
+		return false
+	}
+
+	c.unsubscribe()
+	c.unsubscribe = nil
+
+	return true
+}
+
+// acquire waits until another call can be handled on the connection.
+func (c *jsonrpcConn) acquire() {
+	c.calls <- struct{}{}
+}
+
+// release marks a call on the connection as finished.
+func (c *jsonrpcConn) release() {
+	<-c.calls
+}
+
+// write sends a single message on the connection.
+func (c *jsonrpcConn) write(message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	_, err = c.conn.Write(data)
+
+	return err
+}
+
+func jsonrpcSuccess(id, result json.RawMessage) *jsonrpcResponse {
+	if len(result) == 0 {
+		result = []byte("null")
+	}
+
+	return &jsonrpcResponse{Version: jsonrpcVersion, ID: jsonrpcID(id), Result: result}
+}
+
+func jsonrpcFailure(id json.RawMessage, code int, message string) *jsonrpcResponse {
+	return &jsonrpcResponse{
+		Version: jsonrpcVersion,
+		ID:      jsonrpcID(id),
+		Error:   &jsonrpcError{Code: code, Message: message},
+	}
+}
+
+// jsonrpcUnauthorized returns a failure for a call that was not authorized.
+func jsonrpcUnauthorized(id json.RawMessage, status int, message string) *jsonrpcResponse {
+	failure := jsonrpcFailure(id, jsonrpcServerError, message)
+
+	data, _ := json.Marshal(message)
+	failure.Error.Data, _ = json.Marshal(dispatchResponse{Status: status, Error: data})
+
+	return failure
+}
+
+// jsonrpcID returns the request ID, or null if the ID could not be determined.
+func jsonrpcID(id json.RawMessage) json.RawMessage {
+	if id == nil {
+		return []byte("null")
+	}
+
+	return id
+}
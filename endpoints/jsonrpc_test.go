@@ -0,0 +1,100 @@
+package endpoints
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	ac "github.com/bluetuith-org/api-native/api/appcapability"
+)
+
+// newTestJSONRPCConn serves a JSON-RPC connection, and returns the client side of it.
+func newTestJSONRPCConn(t *testing.T, hub *eventHub) (net.Conn, *bufio.Scanner) {
+	t.Helper()
+
+	router := http.NewServeMux()
+	api, _ := register(router, nil, ac.MergedCollection(), Options{}, false)
+
+	server := NewJSONRPCServer(nil)
+	server.hub, server.calls = hub, newDispatcher(api, router, newAccessControl(nil, nil, nil))
+	t.Cleanup(server.cancel)
+
+	client, conn := net.Pipe()
+	go (&jsonrpcConn{server: server, conn: conn, calls: make(chan struct{}, jsonrpcMaxCalls)}).serve()
+	t.Cleanup(func() { client.Close() })
+
+	client.SetDeadline(time.Now().Add(5 * time.Second))
+
+	return client, bufio.NewScanner(client)
+}
+
+func TestJSONRPCBatch(t *testing.T) {
+	client, scanner := newTestJSONRPCConn(t, newEventHub(0))
+
+	const calls = jsonrpcMaxCalls * 2
+
+	batch := make([]string, 0, calls+1)
+	for i := range calls {
+		batch = append(batch, fmt.Sprintf(`{"jsonrpc": "2.0", "id": %d, "method": "info"}`, i))
+	}
+	batch = append(batch, `{"jsonrpc": "2.0", "method": "info"}`)
+
+	if _, err := fmt.Fprintf(client, "[%s]\n", strings.Join(batch, ",")); err != nil {
+		t.Fatal(err)
+	}
+
+	if !scanner.Scan() {
+		t.Fatalf("No response was received: %v", scanner.Err())
+	}
+
+	var responses []jsonrpcResponse
+	if err := json.Unmarshal(scanner.Bytes(), &responses); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(responses) != calls {
+		t.Fatalf("Received %d responses, want %d", len(responses), calls)
+	}
+	for _, resp := range responses {
+		if resp.Error != nil {
+			t.Errorf("Call %s failed: %s", resp.ID, resp.Error.Message)
+		}
+	}
+}
+
+func TestJSONRPCResubscribe(t *testing.T) {
+	client, scanner := newTestJSONRPCConn(t, newEventHub(0))
+
+	call := func(id int, method string) string {
+		t.Helper()
+
+		if _, err := fmt.Fprintf(client, `{"jsonrpc": "2.0", "id": %d, "method": "%s"}`+"\n", id, method); err != nil {
+			t.Fatal(err)
+		}
+		if !scanner.Scan() {
+			t.Fatalf("No response was received for '%s': %v", method, scanner.Err())
+		}
+
+		var resp jsonrpcResponse
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(resp.Result)
+	}
+
+	call(1, "subscribe")
+	call(2, "subscribe")
+
+	if got := call(3, "unsubscribe"); got != "true" {
+		t.Errorf("First unsubscribe returned %s, want true", got)
+	}
+	if got := call(4, "unsubscribe"); got != "false" {
+		t.Errorf("Second unsubscribe returned %s, want false", got)
+	}
+}
@@ -0,0 +1,136 @@
+package endpoints
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/felixge/httpsnoop"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// accessLogEntry holds the details of a request that are only known once
+// it is routed to an API operation.
+type accessLogEntry struct {
+	operation string
+}
+
+type accessLogKey struct{}
+
+// AccessLog wraps the HTTP handler, so that each request is logged after it is served,
+// along with its operation ID, response status, duration and client address, and the
+// peer credentials or the client certificate name of the client, if any.
+// Responses with a client error are logged as warnings, and server errors as errors.
+func AccessLog(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entry := &accessLogEntry{}
+		ctx := context.WithValue(r.Context(), accessLogKey{}, entry)
+
+		m := httpsnoop.CaptureMetricsFn(w, func(w http.ResponseWriter) {
+			handler.ServeHTTP(w, r.WithContext(ctx))
+		})
+
+		level := slog.LevelInfo
+		switch {
+		case m.Code >= http.StatusInternalServerError:
+			level = slog.LevelError
+
+		case m.Code >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("operation", entry.operation),
+			slog.Int("status", m.Code),
+			slog.Duration("duration", m.Duration),
+			slog.Int64("bytes", m.Written),
+			slog.String("client", r.RemoteAddr),
+		}
+		if caller, ok := CallerFromContext(ctx); ok {
+			attrs = append(attrs,
+				slog.Any("uid", caller.UID),
+				slog.Any("gid", caller.GID),
+				slog.Any("pid", caller.PID),
+			)
+		}
+		if name, ok := ClientCertificateFromContext(ctx); ok {
+			attrs = append(attrs, slog.String("client_cert", name))
+		}
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			attrs = append(attrs, slog.String("trace_id", span.TraceID().String()))
+		}
+
+		slog.LogAttrs(ctx, level, "Request", attrs...)
+	})
+}
+
+// logOperation adds the operation ID to the access log entry of the request.
+func logOperation(ctx huma.Context, next func(huma.Context)) {
+	if entry, ok := ctx.Context().Value(accessLogKey{}).(*accessLogEntry); ok {
+		entry.operation = ctx.Operation().OperationID
+	}
+
+	next(ctx)
+}
+
+// logEvent logs the errors that are published by the Bluetooth stack.
+func logEvent(event hubEvent) {
+	if event.Name != "error" {
+		return
+	}
+
+	// Authorization errors are logged by the authorizer.
+	if _, ok := event.Data.(authErrorEventData); ok {
+		return
+	}
+
+	slog.Error("Native error", "event_id", event.ID, "data", event.Data)
+}
+
+// logAuthRequest logs the creation of an authorization request.
+func logAuthRequest(data AuthRequestEvent) {
+	attrs := []any{
+		"auth_id", data.ID,
+		"auth_type", data.AuthType,
+		"reply_required", data.ReplyRequired,
+	}
+	if data.PairingParams != nil {
+		attrs = append(attrs,
+			"pairing_type", data.PairingParams.PairingType,
+			"address", data.PairingParams.Address.String(),
+		)
+	}
+	if data.TransferParams != nil {
+		attrs = append(attrs, "path", data.TransferParams.Path)
+	}
+	if data.AutoReply != nil {
+		attrs = append(attrs, "rule_id", data.AutoReply.RuleID, "rule_reply", data.AutoReply.Reply)
+	}
+
+	slog.Info("Authorization request created", attrs...)
+}
+
+// logAuthReply logs the outcome of an authorization request that required a reply.
+func logAuthReply(data AuthRequestEvent, started time.Time) {
+	elapsed := time.Since(started)
+
+	switch data.Action {
+	case "expired":
+		slog.Warn("Authorization request timed out", "auth_id", data.ID, "duration", elapsed)
+
+	case "cancelled":
+		slog.Info("Authorization request cancelled", "auth_id", data.ID, "duration", elapsed)
+
+	case "answered":
+		attrs := []any{"auth_id", data.ID, "reply", data.Reply}
+		if data.Reply == "no" {
+			attrs = append(attrs, "reason", data.Reason)
+		}
+
+		slog.Info("Authorization request answered", append(attrs, "duration", elapsed)...)
+	}
+}
@@ -24,6 +24,7 @@ func mediaPlayerPropertiesEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/device/{address}/media_player/properties",
 		Summary:     "Properties",
 		Description: "This endpoint sends a media control command to the device's media player, if available.",
+		Security:    tokenScopes(ScopeRead),
 		Tags:        []string{"Media Player"},
 	}, func(_ context.Context, input *struct {
 		AddressInput
@@ -51,31 +52,35 @@ func mediaPlayerControlEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/device/{address}/media_player/control/{control_type}",
 		Summary:     "Controls",
 		Description: "This endpoint sends a media control command to the device's media player, if available.",
+		Security:    tokenScopes(ScopeControl),
 		Tags:        []string{"Media Player"},
-	}, func(_ context.Context, input *struct {
+		Metadata:    map[string]any{operationMutating: true},
+	}, func(ctx context.Context, input *struct {
 		AddressInput
 		MediaControlInput
 	}) (*struct{}, error) {
-		var err error
+		var control func() error
 
 		mediaCall := session.MediaPlayer(input.Address)
 		switch input.Control {
 		case "play":
-			err = mediaCall.Play()
+			control = mediaCall.Play
 		case "pause":
-			err = mediaCall.Pause()
+			control = mediaCall.Pause
 		case "next":
-			err = mediaCall.Next()
+			control = mediaCall.Next
 		case "previous":
-			err = mediaCall.Previous()
+			control = mediaCall.Previous
 		case "fast-forward":
-			err = mediaCall.FastForward()
+			control = mediaCall.FastForward
 		case "rewind":
-			err = mediaCall.Rewind()
+			control = mediaCall.Rewind
 		case "stop":
-			err = mediaCall.Stop()
+			control = mediaCall.Stop
+		default:
+			return nil, nil
 		}
 
-		return nil, err
+		return nil, sessionCall(ctx, "mediaplayer."+input.Control, input.Address, control)
 	})
 }
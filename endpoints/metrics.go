@@ -0,0 +1,251 @@
+package endpoints
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "bluerestd"
+
+// metrics records the Prometheus metrics of the API, the event hub and the Bluetooth session.
+// The device and adapter gauges are collected from the session during each scrape.
+type metrics struct {
+	registry *prometheus.Registry
+	session  bluetooth.Session
+
+	requests          *prometheus.CounterVec
+	requestDurations  *prometheus.HistogramVec
+	callErrors        *prometheus.CounterVec
+	events            *prometheus.CounterVec
+	transferBytes     *prometheus.CounterVec
+	transferDurations *prometheus.HistogramVec
+
+	devices     *prometheus.Desc
+	discovering *prometheus.Desc
+
+	// transfers holds the ongoing file transfers, and is only accessed by the event listener.
+	transfers map[string]*metricsTransfer
+}
+
+// metricsCallErrorsKey is the context key of the counter of failed native calls for the operation of a request.
+type metricsCallErrorsKey struct{}
+
+// metricsTransfer is the progress of an ongoing file transfer.
+type metricsTransfer struct {
+	started     time.Time
+	transferred uint64
+}
+
+func newMetrics(session bluetooth.Session) *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		session:  session,
+
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "requests_total",
+			Help:      "The number of API requests, by operation ID and response status.",
+		}, []string{"operation", "status"}),
+		requestDurations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "request_duration_seconds",
+			Help:      "The duration of API requests, by operation ID.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		callErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "native_call_errors_total",
+			Help:      "The number of calls into the Bluetooth stack that failed, by the operation ID of the API request that made them.",
+		}, []string{"operation"}),
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "events_total",
+			Help:      "The number of published events, by event name. Native errors are published as 'error' events.",
+		}, []string{"event"}),
+		transferBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "file_transfer_bytes_total",
+			Help:      "The number of bytes transferred in file transfers, by device address.",
+		}, []string{"address"}),
+		transferDurations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "file_transfer_duration_seconds",
+			Help:      "The duration of finished file transfers, by final status.",
+			Buckets:   prometheus.ExponentialBuckets(0.5, 2, 12),
+		}, []string{"status"}),
+
+		devices: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "devices"),
+			"The number of devices associated with an adapter, by state (connected or paired).",
+			[]string{"adapter", "state"}, nil,
+		),
+		discovering: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "adapter_discovering"),
+			"Whether device discovery is active on an adapter.",
+			[]string{"adapter"}, nil,
+		),
+
+		transfers: make(map[string]*metricsTransfer),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests, m.requestDurations, m.callErrors, m.events,
+		m.transferBytes, m.transferDurations,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "auth_requests_pending",
+			Help:      "The number of authorization requests that are waiting for a reply.",
+		}, func() float64 {
+			return float64(requests.Size())
+		}),
+		m,
+	)
+
+	return m
+}
+
+// start records all events from the hub, and the number of its subscribers.
+func (m *metrics) start(hub *eventHub) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "event_subscribers",
+		Help:      "The number of clients subscribed to events.",
+	}, func() float64 {
+		return float64(hub.Subscribers())
+	}))
+
+	hub.Listen(m.recordEvent)
+}
+
+// middleware records the count and duration of all API requests, and passes the counter of
+// failed native calls for the operation to the handler, which is incremented by sessionCall.
+func (m *metrics) middleware(ctx huma.Context, next func(huma.Context)) {
+	started := time.Now()
+	operation := ctx.Operation().OperationID
+
+	next(huma.WithValue(ctx, metricsCallErrorsKey{}, m.callErrors.WithLabelValues(operation)))
+
+	m.requests.WithLabelValues(operation, strconv.Itoa(ctx.Status())).Inc()
+	m.requestDurations.WithLabelValues(operation).Observe(time.Since(started).Seconds())
+}
+
+// recordCallError counts a failed native call for the operation of the request in the context, if any.
+func recordCallError(ctx context.Context) {
+	if counter, ok := ctx.Value(metricsCallErrorsKey{}).(prometheus.Counter); ok {
+		counter.Inc()
+	}
+}
+
+func (m *metrics) recordEvent(event hubEvent) {
+	m.events.WithLabelValues(event.Name).Inc()
+
+	data, ok := event.Data.(bluetooth.FileTransferEventData)
+	if !ok {
+		return
+	}
+
+	address := data.Address.String()
+	key := address + "/" + data.Name
+
+	if data.Action.String() == "removed" {
+		delete(m.transfers, key)
+		return
+	}
+
+	transfer, ok := m.transfers[key]
+	if !ok {
+		transfer = &metricsTransfer{started: time.Now()}
+		m.transfers[key] = transfer
+	}
+
+	if data.Transferred > transfer.transferred {
+		m.transferBytes.WithLabelValues(address).Add(float64(data.Transferred - transfer.transferred))
+		transfer.transferred = data.Transferred
+	}
+
+	// Any status other than an ongoing one is final, such as 'complete', 'error' or 'cancelled'.
+	switch data.Status {
+	case "", "queued", "active", "suspended":
+		return
+	}
+
+	m.transferDurations.WithLabelValues(data.Status).Observe(time.Since(transfer.started).Seconds())
+	delete(m.transfers, key)
+}
+
+// Describe implements prometheus.Collector.
+func (m *metrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.devices
+	ch <- m.discovering
+}
+
+// Collect implements prometheus.Collector, and collects the device and adapter gauges.
+func (m *metrics) Collect(ch chan<- prometheus.Metric) {
+	if m.session == nil {
+		return
+	}
+
+	for _, adapter := range m.session.Adapters() {
+		address := adapter.Address.String()
+
+		ch <- prometheus.MustNewConstMetric(m.discovering, prometheus.GaugeValue, metricsBool(adapter.Discovering), address)
+
+		devices, err := m.session.Adapter(adapter.Address).Devices()
+		if err != nil {
+			continue
+		}
+
+		var connected, paired int
+		for _, device := range devices {
+			if device.Connected {
+				connected++
+			}
+			if device.Paired {
+				paired++
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(m.devices, prometheus.GaugeValue, float64(connected), address, "connected")
+		ch <- prometheus.MustNewConstMetric(m.devices, prometheus.GaugeValue, float64(paired), address, "paired")
+	}
+}
+
+func metricsEndpoint(api huma.API, router *http.ServeMux, m *metrics, access *accessControl) {
+	op := &huma.Operation{
+		OperationID: "metrics",
+		Method:      http.MethodGet,
+		Path:        "/metrics",
+		Summary:     "Metrics",
+		Description: "This endpoint exposes the metrics of the API and the Bluetooth session in the Prometheus text format.",
+		Security:    tokenScopes(ScopeRead),
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "OK",
+				Content: map[string]*huma.MediaType{
+					"text/plain": {Schema: &huma.Schema{Type: huma.TypeString}},
+				},
+			},
+		},
+	}
+	api.OpenAPI().AddOperation(op)
+
+	router.Handle(op.Method+" "+op.Path, access.handler(ScopeRead, promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})))
+}
+
+func metricsBool(value bool) float64 {
+	if value {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,63 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestMetricsCallErrors(t *testing.T) {
+	router := http.NewServeMux()
+	api := humago.New(router, huma.DefaultConfig("Test", "1.0.0"))
+
+	m := newMetrics(nil)
+	api.UseMiddleware(m.middleware)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "native-error",
+		Method:      http.MethodGet,
+		Path:        "/native-error",
+	}, func(ctx context.Context, _ *struct{}) (*struct{}, error) {
+		return nil, sessionCall(ctx, "test.Call", bluetooth.MacAddress{}, func() error {
+			return errors.New("Native error.")
+		})
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "server-error",
+		Method:      http.MethodGet,
+		Path:        "/server-error",
+	}, func(_ context.Context, _ *struct{}) (*struct{}, error) {
+		return nil, huma.Error503ServiceUnavailable("Unavailable.")
+	})
+
+	tests := []struct {
+		operation string
+		want      float64
+	}{
+		{operation: "native-error", want: 1},
+		{operation: "server-error", want: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.operation, func(t *testing.T) {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/"+test.operation, nil))
+
+			var metric dto.Metric
+			if err := m.callErrors.WithLabelValues(test.operation).Write(&metric); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := metric.GetCounter().GetValue(); got != test.want {
+				t.Errorf("Native call errors of '%s' are %v, want %v", test.operation, got, test.want)
+			}
+		})
+	}
+}
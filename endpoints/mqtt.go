@@ -0,0 +1,388 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	ac "github.com/bluetuith-org/api-native/api/appcapability"
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/puzpuzpuz/xsync/v3"
+)
+
+const (
+	// DefaultMQTTTopicPrefix is the default prefix of all MQTT topics.
+	DefaultMQTTTopicPrefix = "bluerestd"
+
+	mqttQOS        = 1
+	mqttTimeout    = 10 * time.Second
+	mqttNoAddress  = "_"
+	mqttOnline     = "online"
+	mqttOffline    = "offline"
+	mqttDisconnect = 250
+)
+
+// MQTTConfig describes the connection to an MQTT broker.
+type MQTTConfig struct {
+	Broker      string
+	ClientID    string
+	Username    string
+	Password    string
+	TopicPrefix string
+
+	// Token, if set, is the API token that authorizes the commands. If the API
+	// requires tokens and none is set, all commands are rejected.
+	Token string
+
+	// HomeAssistant enables Home Assistant MQTT discovery for all paired devices,
+	// with the discovery topics published under HomeAssistantPrefix.
+	HomeAssistant       bool
+	HomeAssistantPrefix string
+}
+
+// MQTTBridge publishes events and the state of all adapters and devices to an MQTT broker,
+// and handles commands for devices and adapters. The following topics are used:
+//   - '<prefix>/status': The (retained) status of the bridge, either 'online' or 'offline'.
+//   - '<prefix>/<adapter>/state': The (retained) properties of an adapter.
+//   - '<prefix>/<adapter>/<device>/state': The (retained) properties of a device.
+//   - '<prefix>/<adapter>/<device>/homeassistant': The (retained) state of a device for Home Assistant, if enabled.
+//   - '<prefix>/<adapter>/adapter': The adapter events.
+//   - '<prefix>/<adapter>/<device>/<event>': The events associated with a device.
+//   - '<prefix>/<event>': All other events.
+//   - '<prefix>/<adapter>/<device>/command': Device commands, with a payload of 'connect', 'disconnect',
+//     or a media player command ('play', 'pause', 'next', 'previous', 'fast-forward', 'rewind', 'stop').
+//   - '<prefix>/<adapter>/<state>/set': Adapter state commands, where state is one of 'powered',
+//     'pairable', 'discoverable' or 'discovery', with a payload of 'enable' or 'disable'.
+//
+// The commands are dispatched to their API operations, so that they are authorized with the
+// configured token and recorded like any other API call. The result of each command is
+// published to the '<command topic>/result' topic.
+type MQTTBridge struct {
+	config  MQTTConfig
+	client  mqtt.Client
+	session bluetooth.Session
+	calls   *dispatcher
+
+	// adapters maps device addresses to the addresses of their associated adapters.
+	adapters *xsync.MapOf[string, string]
+
+	homeAssistant *homeAssistant
+}
+
+// mqttCommandResult is published after a command is handled.
+type mqttCommandResult struct {
+	Command string `json:"command"`
+	Error   string `json:"error,omitempty"`
+}
+
+// NewMQTTBridge returns a new MQTT bridge. The connection to the broker
+// is established only after the API is registered.
+func NewMQTTBridge(config MQTTConfig) (*MQTTBridge, error) {
+	if config.Broker == "" {
+		return nil, errors.New("No MQTT broker address specified")
+	}
+
+	if config.TopicPrefix == "" {
+		config.TopicPrefix = DefaultMQTTTopicPrefix
+	}
+	config.TopicPrefix = strings.TrimSuffix(config.TopicPrefix, "/")
+
+	if config.ClientID == "" {
+		config.ClientID = config.TopicPrefix
+	}
+
+	return &MQTTBridge{
+		config:   config,
+		adapters: xsync.NewMapOf[string, string](),
+	}, nil
+}
+
+// Close marks the bridge as offline and disconnects from the broker.
+func (m *MQTTBridge) Close() {
+	if m.client == nil {
+		return
+	}
+
+	m.client.Publish(m.topic("status"), mqttQOS, true, mqttOffline).WaitTimeout(mqttTimeout)
+	m.client.Disconnect(mqttDisconnect)
+}
+
+// start connects to the broker, and publishes all subsequent events from the hub.
+// The connection is retried in the background until it succeeds.
+func (m *MQTTBridge) start(hub *eventHub, session bluetooth.Session, collection ac.Collection, calls *dispatcher) {
+	m.session, m.calls = session, calls
+	if m.config.HomeAssistant {
+		m.homeAssistant = newHomeAssistant(m.config.HomeAssistantPrefix, collection)
+	}
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(m.config.Broker).
+		SetClientID(m.config.ClientID).
+		SetUsername(m.config.Username).
+		SetPassword(m.config.Password).
+		SetWill(m.topic("status"), mqttOffline, mqttQOS, true).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetOrderMatters(false).
+		SetOnConnectHandler(m.onConnect)
+
+	m.client = mqtt.NewClient(opts)
+	m.client.Connect()
+
+	hub.Listen(m.publishEvent)
+}
+
+// onConnect is called after every (re)connection to the broker. It publishes the
+// current state of all adapters and devices, and subscribes to the command topics.
+func (m *MQTTBridge) onConnect(_ mqtt.Client) {
+	m.publish(m.topic("status"), true, mqttOnline)
+
+	m.subscribe(m.topic("+", "+", "command"), m.onDeviceCommand)
+	m.subscribe(m.topic("+", "+", "set"), m.onAdapterCommand)
+	if m.homeAssistant != nil {
+		m.homeAssistant.subscribe(m)
+	}
+
+	m.publishAllStates()
+}
+
+// publishAllStates publishes the states of all adapters and their devices.
+func (m *MQTTBridge) publishAllStates() {
+	for _, adapter := range m.session.Adapters() {
+		m.publishAdapterState(adapter.Address)
+
+		devices, err := m.session.Adapter(adapter.Address).Devices()
+		if err != nil {
+			continue
+		}
+
+		for _, device := range devices {
+			m.publishDeviceState(device.Address, "")
+		}
+	}
+}
+
+func (m *MQTTBridge) publishEvent(event hubEvent) {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return
+	}
+
+	props := event.Properties()
+	if props.device != "" && props.adapter == "" {
+		props.adapter, _ = m.adapters.Load(props.device)
+	}
+
+	var topic string
+	switch {
+	case event.Name == "adapter" && props.adapter != "":
+		topic = m.topic(props.adapter, event.Name)
+
+	case props.device != "":
+		topic = m.topic(orNoAddress(props.adapter), props.device, event.Name)
+
+	default:
+		topic = m.topic(event.Name)
+	}
+
+	m.publish(topic, false, payload)
+
+	switch event.Name {
+	case "adapter":
+		if mac, err := bluetooth.ParseMAC(props.adapter); err == nil {
+			m.publishAdapterState(mac)
+		}
+
+	case "device":
+		if mac, err := bluetooth.ParseMAC(props.device); err == nil {
+			m.publishDeviceState(mac, props.action)
+		}
+	}
+}
+
+func (m *MQTTBridge) publishAdapterState(address bluetooth.MacAddress) {
+	properties, err := m.session.Adapter(address).Properties()
+	if err != nil {
+		return
+	}
+
+	payload, err := json.Marshal(properties)
+	if err != nil {
+		return
+	}
+
+	m.publish(m.topic(address.String(), "state"), true, payload)
+}
+
+// publishDeviceState publishes the properties of the device as a retained message.
+// If the device was removed, the retained message is cleared.
+func (m *MQTTBridge) publishDeviceState(address bluetooth.MacAddress, action string) {
+	if action == "removed" {
+		if adapter, ok := m.adapters.LoadAndDelete(address.String()); ok {
+			m.publish(m.topic(adapter, address.String(), "state"), true, []byte{})
+		}
+		if m.homeAssistant != nil {
+			m.homeAssistant.removeDevice(m, address)
+		}
+
+		return
+	}
+
+	properties, err := m.session.Device(address).Properties()
+	if err != nil {
+		return
+	}
+
+	payload, err := json.Marshal(properties)
+	if err != nil {
+		return
+	}
+
+	adapter := orNoAddress(properties.AssociatedAdapter.String())
+	m.adapters.Store(address.String(), adapter)
+
+	m.publish(m.topic(adapter, address.String(), "state"), true, payload)
+	if m.homeAssistant != nil {
+		m.homeAssistant.publishDevice(m, adapter, address, properties)
+	}
+}
+
+func (m *MQTTBridge) onDeviceCommand(_ mqtt.Client, msg mqtt.Message) {
+	command := strings.TrimSpace(string(msg.Payload()))
+	levels := strings.Split(strings.TrimPrefix(msg.Topic(), m.config.TopicPrefix+"/"), "/")
+
+	m.reply(msg.Topic(), command, func() error {
+		req := dispatchRequest{Params: map[string]any{"address": levels[1]}}
+
+		switch command {
+		case "connect":
+			req.OperationID = "device-connect"
+		case "disconnect":
+			req.OperationID = "device-disconnect"
+		case "play", "pause", "next", "previous", "fast-forward", "rewind", "stop":
+			req.OperationID = "device-media-player-controls"
+			req.Params["control_type"] = command
+		default:
+			return fmt.Errorf("Unknown device command '%s'", command)
+		}
+
+		return m.call(req)
+	})
+}
+
+func (m *MQTTBridge) onAdapterCommand(_ mqtt.Client, msg mqtt.Message) {
+	toggle := strings.ToLower(strings.TrimSpace(string(msg.Payload())))
+	levels := strings.Split(strings.TrimPrefix(msg.Topic(), m.config.TopicPrefix+"/"), "/")
+
+	m.reply(msg.Topic(), levels[1]+"="+toggle, func() error {
+		switch levels[1] {
+		case "powered", "pairable", "discoverable", "discovery":
+		default:
+			return fmt.Errorf("Unknown adapter state '%s'", levels[1])
+		}
+
+		switch toggle {
+		case "enable", "on", "true":
+			toggle = "enable"
+		case "disable", "off", "false":
+			toggle = "disable"
+		default:
+			return fmt.Errorf("Invalid state '%s', must be one of 'enable' or 'disable'", toggle)
+		}
+
+		err := m.call(dispatchRequest{
+			OperationID: "adapter-states",
+			Params:      map[string]any{"address": levels[0], levels[1]: toggle},
+		})
+		if err == nil {
+			if address, perr := bluetooth.ParseMAC(levels[0]); perr == nil {
+				m.publishAdapterState(address)
+			}
+		}
+
+		return err
+	})
+}
+
+// call dispatches the command to its API operation, authorized with the configured token.
+func (m *MQTTBridge) call(req dispatchRequest) error {
+	header := make(http.Header)
+	if m.config.Token != "" {
+		header.Set("Authorization", "Bearer "+m.config.Token)
+	}
+
+	resp, err := m.calls.Call(context.Background(), header, req)
+	if err != nil {
+		return err
+	}
+
+	if resp.Status >= http.StatusBadRequest {
+		return errors.New(resp.ErrorMessage())
+	}
+
+	return nil
+}
+
+// reply runs the command handler in the background, and publishes its result.
+func (m *MQTTBridge) reply(topic, command string, handler func() error) {
+	go func() {
+		result := mqttCommandResult{Command: command}
+		if err := handler(); err != nil {
+			result.Error = err.Error()
+		}
+
+		payload, err := json.Marshal(result)
+		if err != nil {
+			return
+		}
+
+		m.publish(topic+"/result", false, payload)
+	}()
+}
+
+// publish publishes the payload to the topic. Since the messages are sent in the background,
+// an error is only logged once the broker has rejected the message or the connection is lost.
+func (m *MQTTBridge) publish(topic string, retained bool, payload any) {
+	token := m.client.Publish(topic, mqttQOS, retained, payload)
+
+	go func() {
+		<-token.Done()
+		if err := token.Error(); err != nil {
+			slog.Warn("Cannot publish to the MQTT broker", "topic", topic, "error", err)
+		}
+	}()
+}
+
+// subscribe subscribes to the topic, and logs an error if the subscription fails.
+func (m *MQTTBridge) subscribe(topic string, handler mqtt.MessageHandler) {
+	token := m.client.Subscribe(topic, mqttQOS, handler)
+
+	var err error
+	if !token.WaitTimeout(mqttTimeout) {
+		err = errors.New("The subscription timed out")
+	} else {
+		err = token.Error()
+	}
+
+	if err != nil {
+		slog.Error("Cannot subscribe to the MQTT topic", "topic", topic, "error", err)
+	}
+}
+
+func (m *MQTTBridge) topic(levels ...string) string {
+	return m.config.TopicPrefix + "/" + strings.Join(levels, "/")
+}
+
+func orNoAddress(address string) string {
+	if address == "" {
+		return mqttNoAddress
+	}
+
+	return address
+}
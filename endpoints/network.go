@@ -25,8 +25,10 @@ func connectNetworkEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/device/{address}/network_connect/{connection_type}",
 		Summary:     "Connection (PANU, DUN)",
 		Description: "This endpoint attempts to tether to the internet connection of the device.",
+		Security:    tokenScopes(ScopeControl),
 		Tags:        []string{"Network"},
-	}, func(_ context.Context, input *struct {
+		Metadata:    map[string]any{operationMutating: true},
+	}, func(ctx context.Context, input *struct {
 		AddressInput
 		NetworkTypeInput
 	}) (*struct{}, error) {
@@ -37,7 +39,9 @@ func connectNetworkEndpoint(api huma.API, session bluetooth.Session) {
 
 		networkName := device.Name + " Connection (" + device.Address.String() + ", " + strings.ToUpper(input.Type.String()) + ")"
 
-		return nil, session.Network(input.Address).Connect(networkName, input.Type)
+		return nil, sessionCall(ctx, "network.Connect", input.Address, func() error {
+			return session.Network(input.Address).Connect(networkName, input.Type)
+		})
 	})
 }
 
@@ -48,10 +52,12 @@ func disconnectNetworkEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/device/{address}/network_disconnect",
 		Summary:     "Disconnection",
 		Description: "This endpoint attempts to untether from the internet connection of the device.",
+		Security:    tokenScopes(ScopeControl),
 		Tags:        []string{"Network"},
-	}, func(_ context.Context, input *struct {
+		Metadata:    map[string]any{operationMutating: true},
+	}, func(ctx context.Context, input *struct {
 		AddressInput
 	}) (*struct{}, error) {
-		return nil, session.Network(input.Address).Disconnect()
+		return nil, sessionCall(ctx, "network.Disconnect", input.Address, session.Network(input.Address).Disconnect)
 	})
 }
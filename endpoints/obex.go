@@ -20,11 +20,13 @@ func cancelTransferEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/device/{address}/stop_file_transfer",
 		Summary:     "Stop Transfers",
 		Description: "This endpoint attempts to stop an ongoing file transfer session.",
+		Security:    tokenScopes(ScopeFiles),
 		Tags:        []string{"File Transfer"},
+		Metadata:    map[string]any{operationMutating: true},
 	}, func(ctx context.Context, input *struct {
 		AddressInput
 	}) (*struct{}, error) {
-		return nil, session.Obex(input.Address).FileTransfer().CancelTransfer()
+		return nil, sessionCall(ctx, "obex.CancelTransfer", input.Address, session.Obex(input.Address).FileTransfer().CancelTransfer)
 	})
 }
 
@@ -47,7 +49,9 @@ func startTransferEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/device/{address}/start_file_transfer",
 		Summary:     "Start Transfers",
 		Description: "This endpoint attempts to send files to a device. If files are queued, monitor the `filetransfer` event in the `/events` stream for all ongoing file transfer events.",
+		Security:    tokenScopes(ScopeFiles),
 		Tags:        []string{"File Transfer"},
+		Metadata:    map[string]any{operationMutating: true},
 	}, func(ctx context.Context, input *struct {
 		AddressInput
 		SendFilesInput
@@ -57,7 +61,9 @@ func startTransferEndpoint(api huma.API, session bluetooth.Session) {
 		}
 
 		obexCall := session.Obex(input.Address)
-		if err := obexCall.FileTransfer().CreateSession(ctx); err != nil {
+		if err := sessionCall(ctx, "obex.CreateSession", input.Address, func() error {
+			return obexCall.FileTransfer().CreateSession(ctx)
+		}); err != nil {
 			return nil, err
 		}
 
@@ -67,12 +73,15 @@ func startTransferEndpoint(api huma.API, session bluetooth.Session) {
 		for _, file := range input.Body.FilePaths {
 			select {
 			case <-ctx.Done():
-				return nil, obexCall.FileTransfer().CancelTransfer()
+				return nil, sessionCall(ctx, "obex.CancelTransfer", input.Address, obexCall.FileTransfer().CancelTransfer)
 			default:
 			}
 
-			data, err := obexCall.FileTransfer().SendFile(file)
-			if err != nil {
+			var data bluetooth.FileTransferData
+			if err := sessionCall(ctx, "obex.SendFile", input.Address, func() (err error) {
+				data, err = obexCall.FileTransfer().SendFile(file)
+				return err
+			}); err != nil {
 				return nil, err
 			}
 
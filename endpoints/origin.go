@@ -0,0 +1,212 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// originRequestHeader is the custom header that marks a request as one that is
+// not sent by a plain HTML form or link, since browsers only send it cross-origin
+// after a successful CORS preflight.
+const originRequestHeader = "X-Bluerestd-Request"
+
+// OriginConfig describes the hosts and origins that are allowed to call the API.
+type OriginConfig struct {
+	// AllowedHosts are the host names or IP addresses (without ports) that may be sent in
+	// the 'Host' header, to protect against DNS rebinding. If empty, any host is allowed,
+	// and only the allowed origins may call the API from a browser.
+	AllowedHosts []string
+
+	// AllowedOrigins are the origins (for example, 'https://dashboard.local:8080') that may
+	// call the API from a browser, or '*' for any origin. Same-origin requests are allowed
+	// if the allowed hosts are set.
+	AllowedOrigins []string
+
+	// MaxAge is the duration for which browsers may cache the CORS preflight responses.
+	MaxAge time.Duration
+
+	// StrictRequests also rejects state-changing form POST requests, which browsers send
+	// cross-origin without a CORS preflight, if they do not send the 'X-Bluerestd-Request'
+	// or 'Authorization' headers. State-changing GET requests are always rejected without them.
+	StrictRequests bool
+}
+
+// OriginPolicy validates the 'Host' and 'Origin' headers of requests, and serves the CORS headers
+// for the allowed origins, so that web pages cannot call the API unless they are allowed to.
+type OriginPolicy struct {
+	config OriginConfig
+
+	hosts     []string
+	origins   []string
+	anyOrigin bool
+}
+
+type originKey struct{}
+
+// corsAllowedHeaders are the request headers that allowed origins may send.
+var corsAllowedHeaders = []string{"Authorization", "Content-Type", "Last-Event-ID", originRequestHeader}
+
+// NewOriginPolicy validates the hosts and origins in the configuration.
+func NewOriginPolicy(config OriginConfig) (*OriginPolicy, error) {
+	p := &OriginPolicy{config: config}
+
+	for _, host := range config.AllowedHosts {
+		p.hosts = append(p.hosts, strings.ToLower(strings.Trim(host, "[]")))
+	}
+
+	for _, origin := range config.AllowedOrigins {
+		if origin == "*" {
+			p.anyOrigin = true
+			continue
+		}
+
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			return nil, fmt.Errorf("Invalid origin '%s', must be of the form 'scheme://host[:port]'", origin)
+		}
+
+		p.origins = append(p.origins, strings.ToLower(u.Scheme+"://"+u.Host))
+	}
+
+	return p, nil
+}
+
+// Handler wraps the HTTP handler, so that requests with a host or origin that is not
+// allowed are rejected, and CORS preflight requests from allowed origins are answered.
+func (p *OriginPolicy) Handler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !p.hostAllowed(r.Host) {
+			http.Error(w, fmt.Sprintf("The host '%s' is not allowed.", r.Host), http.StatusForbidden)
+			return
+		}
+
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		// The 'Host' header is only trusted to determine the origin of the API if it is validated.
+		if len(p.hosts) == 0 || !sameOrigin(origin, r.Host) {
+			if !p.originAllowed(origin) {
+				http.Error(w, fmt.Sprintf("The origin '%s' is not allowed.", origin), http.StatusForbidden)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Expose-Headers", "Retry-After")
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE")
+				w.Header().Set("Access-Control-Allow-Headers", strings.Join(corsAllowedHeaders, ", "))
+				if p.config.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(p.config.MaxAge.Seconds())))
+				}
+
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), originKey{}, true)))
+	})
+}
+
+// middleware rejects state-changing requests that browsers send without a CORS preflight, which are
+// GET requests, and form POST requests if strict requests are enabled. Dispatched calls are trusted,
+// since they are sent over connections that are authorized and origin-checked when they are established.
+func (p *OriginPolicy) middleware(api huma.API) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if !isMutating(ctx) || dispatched(ctx.Context()) || !simpleRequest(ctx) {
+			next(ctx)
+			return
+		}
+
+		switch ctx.Method() {
+		case http.MethodPost:
+			if p.config.StrictRequests {
+				huma.WriteErr(api, ctx, http.StatusForbidden, fmt.Sprintf(
+					"State-changing POST requests must send the '%s' or the 'Authorization' header, or a JSON body.", originRequestHeader,
+				))
+
+				return
+			}
+
+		default:
+			huma.WriteErr(api, ctx, http.StatusForbidden, fmt.Sprintf(
+				"State-changing GET requests must send the '%s' or the 'Authorization' header.", originRequestHeader,
+			))
+
+			return
+		}
+
+		next(ctx)
+	}
+}
+
+// originVerified reports whether the request in the context has an 'Origin' header that is allowed.
+func originVerified(ctx context.Context) bool {
+	verified, _ := ctx.Value(originKey{}).(bool)
+
+	return verified
+}
+
+func (p *OriginPolicy) hostAllowed(host string) bool {
+	if len(p.hosts) == 0 {
+		return true
+	}
+
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return slices.Contains(p.hosts, strings.ToLower(strings.Trim(host, "[]")))
+}
+
+func (p *OriginPolicy) originAllowed(origin string) bool {
+	return p.anyOrigin || slices.Contains(p.origins, strings.ToLower(origin))
+}
+
+// sameOrigin reports whether the origin refers to the host that the request was sent to.
+func sameOrigin(origin, host string) bool {
+	u, err := url.Parse(origin)
+
+	return err == nil && strings.EqualFold(u.Host, host)
+}
+
+// simpleRequest reports whether the request is one which browsers send cross-origin
+// without a CORS preflight.
+func simpleRequest(ctx huma.Context) bool {
+	switch ctx.Method() {
+	case http.MethodGet, http.MethodHead, http.MethodPost:
+	default:
+		return false
+	}
+
+	if ctx.Header(originRequestHeader) != "" || ctx.Header("Authorization") != "" {
+		return false
+	}
+
+	if ctx.Method() != http.MethodPost {
+		return true
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(ctx.Header("Content-Type"))
+	switch mediaType {
+	case "", "application/x-www-form-urlencoded", "multipart/form-data", "text/plain":
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package endpoints
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+)
+
+func TestOriginPolicyMutatingRequests(t *testing.T) {
+	newRouter := func(strict bool) http.Handler {
+		router := http.NewServeMux()
+		api := humago.New(router, huma.DefaultConfig("Test", "1.0.0"))
+
+		policy, err := NewOriginPolicy(OriginConfig{StrictRequests: strict})
+		if err != nil {
+			t.Fatal(err)
+		}
+		api.UseMiddleware(policy.middleware(api))
+
+		for _, method := range []string{http.MethodGet, http.MethodPost} {
+			huma.Register(api, huma.Operation{
+				OperationID: "mutate-" + strings.ToLower(method),
+				Method:      method,
+				Path:        "/mutate",
+				Metadata:    map[string]any{operationMutating: true},
+			}, func(_ context.Context, _ *struct{}) (*struct{}, error) {
+				return nil, nil
+			})
+		}
+
+		return policy.Handler(router)
+	}
+
+	routers := map[bool]http.Handler{false: newRouter(false), true: newRouter(true)}
+
+	tests := []struct {
+		name        string
+		strict      bool
+		method      string
+		header      string
+		contentType string
+		want        int
+	}{
+		{name: "GET", method: http.MethodGet, want: http.StatusForbidden},
+		{name: "GET with request header", method: http.MethodGet, header: originRequestHeader, want: http.StatusNoContent},
+		{name: "GET with authorization", method: http.MethodGet, header: "Authorization", want: http.StatusNoContent},
+		{name: "form POST", method: http.MethodPost, contentType: "application/x-www-form-urlencoded", want: http.StatusNoContent},
+		{name: "strict GET", strict: true, method: http.MethodGet, want: http.StatusForbidden},
+		{name: "strict form POST", strict: true, method: http.MethodPost, contentType: "text/plain", want: http.StatusForbidden},
+		{name: "strict JSON POST", strict: true, method: http.MethodPost, contentType: "application/json", want: http.StatusNoContent},
+		{name: "strict POST with request header", strict: true, method: http.MethodPost, header: originRequestHeader, want: http.StatusNoContent},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, "/mutate", nil)
+			if test.header != "" {
+				req.Header.Set(test.header, "1")
+			}
+			if test.contentType != "" {
+				req.Header.Set("Content-Type", test.contentType)
+			}
+
+			resp := httptest.NewRecorder()
+			routers[test.strict].ServeHTTP(resp, req)
+
+			if resp.Code != test.want {
+				t.Errorf("Request returned status %d, want %d", resp.Code, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os/user"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/puzpuzpuz/xsync/v3"
+)
+
+// peerGroupsExpiry is the duration for which the group memberships of a user are cached.
+const peerGroupsExpiry = time.Minute
+
+// Caller identifies the process that is connected to a UNIX socket.
+type Caller struct {
+	UID uint32 `json:"uid" doc:"The user ID of the calling process."`
+	GID uint32 `json:"gid" doc:"The group ID of the calling process."`
+	PID int32  `json:"pid" doc:"The process ID of the calling process."`
+}
+
+type callerKey struct{}
+
+// PeerPolicy authorizes UNIX socket clients using their peer credentials. Each scope
+// has an allowlist of users and groups, and a client is granted a scope if its user, or
+// any of the groups that its user is a member of, is in the allowlist of the scope.
+type PeerPolicy struct {
+	users  map[string][]uint32
+	groups map[string][]uint32
+
+	memberships *xsync.MapOf[uint32, peerGroups]
+}
+
+// peerGroups holds the cached group memberships of a user.
+type peerGroups struct {
+	gids    []uint32
+	expires time.Time
+}
+
+// NewPeerPolicy parses the allowlist rules. Each rule is of the form 'scope=principal,...',
+// where scope is one of the token scopes (or '*' for all scopes), and each principal is
+// either a user name or user ID, or a group name or group ID prefixed with '@'.
+func NewPeerPolicy(rules []string) (*PeerPolicy, error) {
+	p := &PeerPolicy{
+		users:       make(map[string][]uint32),
+		groups:      make(map[string][]uint32),
+		memberships: xsync.NewMapOf[uint32, peerGroups](),
+	}
+
+	for _, rule := range rules {
+		scopes, principals, err := parseScopeRule(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, principal := range principals {
+			if group, ok := strings.CutPrefix(principal, "@"); ok {
+				gid, err := lookupGroupID(group)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, scope := range scopes {
+					p.groups[scope] = append(p.groups[scope], gid)
+				}
+
+				continue
+			}
+
+			uid, err := lookupUserID(principal)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, scope := range scopes {
+				p.users[scope] = append(p.users[scope], uid)
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// CallerFromContext returns the peer credentials of the UNIX socket client that made the call.
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(Caller)
+
+	return caller, ok
+}
+
+// authorize checks whether the caller has been granted the scope.
+// If scope is empty, the caller must have been granted any scope.
+func (p *PeerPolicy) authorize(caller Caller, scope string) (int, string) {
+	scopes := []string{scope}
+	if scope == "" {
+		scopes = TokenScopes
+	}
+
+	gids := p.groupIDs(caller)
+	for _, scope := range scopes {
+		if slices.Contains(p.users[scope], caller.UID) {
+			return http.StatusOK, ""
+		}
+
+		for _, gid := range p.groups[scope] {
+			if slices.Contains(gids, gid) {
+				return http.StatusOK, ""
+			}
+		}
+	}
+
+	if scope == "" {
+		return http.StatusForbidden, fmt.Sprintf("User %d is not allowed to use the API.", caller.UID)
+	}
+
+	return http.StatusForbidden, fmt.Sprintf("User %d is not allowed the '%s' scope.", caller.UID, scope)
+}
+
+// groupIDs returns the primary group of the caller, and the groups that its user is a member of.
+func (p *PeerPolicy) groupIDs(caller Caller) []uint32 {
+	groups, ok := p.memberships.Load(caller.UID)
+	if !ok || time.Now().After(groups.expires) {
+		groups = peerGroups{expires: time.Now().Add(peerGroupsExpiry)}
+
+		if u, err := user.LookupId(strconv.FormatUint(uint64(caller.UID), 10)); err == nil {
+			ids, err := u.GroupIds()
+			if err != nil {
+				slog.Warn("Cannot look up the groups of a socket client", "uid", caller.UID, "error", err)
+			}
+
+			for _, id := range ids {
+				if gid, err := strconv.ParseUint(id, 10, 32); err == nil {
+					groups.gids = append(groups.gids, uint32(gid))
+				}
+			}
+		}
+
+		p.memberships.Store(caller.UID, groups)
+	}
+
+	return append([]uint32{caller.GID}, groups.gids...)
+}
+
+func lookupUserID(name string) (uint32, error) {
+	if uid, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return uint32(uid), nil
+	}
+
+	u, err := user.Lookup(name)
+	if err != nil {
+		return 0, fmt.Errorf("Cannot find user '%s': %w", name, err)
+	}
+
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("User '%s' does not have a numeric user ID", name)
+	}
+
+	return uint32(uid), nil
+}
+
+func lookupGroupID(name string) (uint32, error) {
+	if gid, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return uint32(gid), nil
+	}
+
+	g, err := user.LookupGroup(name)
+	if err != nil {
+		return 0, fmt.Errorf("Cannot find group '%s': %w", name, err)
+	}
+
+	gid, err := strconv.ParseUint(g.Gid, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("Group '%s' does not have a numeric group ID", name)
+	}
+
+	return uint32(gid), nil
+}
@@ -0,0 +1,29 @@
+package endpoints
+
+import (
+	"net"
+	"syscall"
+)
+
+// peerCredentials returns the credentials of the process connected to a UNIX socket, using SO_PEERCRED.
+func peerCredentials(conn net.Conn) (Caller, bool) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return Caller{}, false
+	}
+
+	raw, err := unixConn.SyscallConn()
+	if err != nil {
+		return Caller{}, false
+	}
+
+	var cred *syscall.Ucred
+	var credErr error
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil || credErr != nil {
+		return Caller{}, false
+	}
+
+	return Caller{UID: cred.Uid, GID: cred.Gid, PID: cred.Pid}, true
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package endpoints
+
+import "net"
+
+// peerCredentials is only supported on Linux, so no credentials are returned.
+func peerCredentials(_ net.Conn) (Caller, bool) {
+	return Caller{}, false
+}
@@ -0,0 +1,266 @@
+package endpoints
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+	"github.com/bluetuith-org/api-native/api/eventbus"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+const (
+	// DefaultPresenceTimeout is the default duration after which a device that
+	// has not been seen is considered to have left.
+	DefaultPresenceTimeout = 2 * time.Minute
+
+	presenceEvent = presenceEventID(101)
+)
+
+// PresenceConfig describes how device presence is determined.
+type PresenceConfig struct {
+	// Timeout is the duration after which a device that has not been seen is considered to have left.
+	Timeout time.Duration
+
+	// Hysteresis is the duration for which a device has to be seen continuously
+	// before it is considered to have arrived.
+	Hysteresis time.Duration
+
+	// MinRSSI, if non-zero, is the minimum signal strength for a device to be considered seen.
+	MinRSSI int
+}
+
+type presenceEventID uint
+
+// presenceEventData is published when a device arrives or leaves.
+type presenceEventData struct {
+	Action string        `json:"action" enum:"arrived,left" doc:"Whether the device has arrived or left."`
+	Device presenceState `json:"device" doc:"The presence state of the device."`
+}
+
+type presenceState struct {
+	Address   bluetooth.MacAddress `json:"address" doc:"The address of the device."`
+	Name      string               `json:"name,omitempty" doc:"The name of the device."`
+	Present   bool                 `json:"present" doc:"Whether the device is present."`
+	RSSI      int                  `json:"rssi,omitempty" doc:"The last received signal strength of the device."`
+	Connected bool                 `json:"connected" doc:"Whether the device was connected when it was last seen."`
+	FirstSeen time.Time            `json:"first_seen" doc:"The time at which the device was first seen, since it was last absent."`
+	LastSeen  time.Time            `json:"last_seen" doc:"The time at which the device was last seen."`
+}
+
+// PresenceTracker derives the presence of devices from the device events, and publishes
+// a 'presence' event whenever a device arrives or leaves.
+type PresenceTracker struct {
+	config  PresenceConfig
+	session bluetooth.Session
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	devices map[string]*presenceState
+}
+
+// NewPresenceTracker returns a tracker which determines the presence of devices using the provided configuration.
+func NewPresenceTracker(config PresenceConfig) *PresenceTracker {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultPresenceTimeout
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &PresenceTracker{
+		config:  config,
+		ctx:     ctx,
+		cancel:  cancel,
+		devices: make(map[string]*presenceState),
+	}
+}
+
+// Close stops checking for devices that have left.
+func (p *PresenceTracker) Close() {
+	p.cancel()
+}
+
+// start tracks all device events from the hub, and periodically checks for devices that have left.
+func (p *PresenceTracker) start(hub *eventHub, session bluetooth.Session) {
+	p.session = session
+
+	hub.Listen(func(event hubEvent) {
+		if event.Name == "device" {
+			p.seen(event)
+		}
+	})
+
+	go func() {
+		ticker := time.NewTicker(max(p.config.Timeout/10, time.Second))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				p.sweep()
+
+			case <-p.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// seen records a sighting of the device associated with the event.
+func (p *PresenceTracker) seen(event hubEvent) {
+	data, ok := event.Data.(bluetooth.DeviceEventData)
+	if !ok || data.Action.String() == "removed" {
+		return
+	}
+
+	address := data.Address.String()
+	if address == "" {
+		return
+	}
+
+	if p.config.MinRSSI != 0 && data.RSSI != 0 && int(data.RSSI) < p.config.MinRSSI {
+		return
+	}
+
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state, ok := p.devices[address]
+	if !ok || (!state.Present && now.Sub(state.LastSeen) > p.config.Timeout) {
+		state = &presenceState{Address: data.Address, FirstSeen: now}
+		p.devices[address] = state
+	}
+
+	state.LastSeen = now
+	state.Connected = data.Connected
+	if data.RSSI != 0 {
+		state.RSSI = int(data.RSSI)
+	}
+	if data.Name != "" {
+		state.Name = data.Name
+	}
+
+	if !state.Present && now.Sub(state.FirstSeen) >= p.config.Hysteresis {
+		state.Present = true
+		p.publish("arrived", state)
+	}
+}
+
+// sweep publishes devices which have arrived after the hysteresis duration, and
+// devices which have not been seen within the timeout. Connected devices are
+// considered to be present, even if they have not published any events.
+func (p *PresenceTracker) sweep() {
+	now := time.Now()
+
+	var candidates []bluetooth.MacAddress
+	if p.session != nil {
+		p.mu.Lock()
+		for _, state := range p.devices {
+			if now.Sub(state.LastSeen) > p.config.Timeout && state.Connected {
+				candidates = append(candidates, state.Address)
+			}
+		}
+		p.mu.Unlock()
+	}
+
+	// The connection states are queried without holding the lock, since each query is a call into the session.
+	connected := make(map[string]bool, len(candidates))
+	for _, address := range candidates {
+		if properties, err := p.session.Device(address).Properties(); err == nil && properties.Connected {
+			connected[address.String()] = true
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for address, state := range p.devices {
+		if now.Sub(state.LastSeen) <= p.config.Timeout {
+			if !state.Present && now.Sub(state.FirstSeen) >= p.config.Hysteresis {
+				state.Present = true
+				p.publish("arrived", state)
+			}
+
+			continue
+		}
+
+		if state.Connected && connected[address] {
+			state.LastSeen = now
+			continue
+		}
+
+		if state.Present {
+			state.Present, state.Connected = false, false
+			p.publish("left", state)
+			continue
+		}
+
+		delete(p.devices, address)
+	}
+}
+
+// States returns the presence states of all tracked devices.
+func (p *PresenceTracker) States() []presenceState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	states := make([]presenceState, 0, len(p.devices))
+	for _, state := range p.devices {
+		states = append(states, *state)
+	}
+
+	slices.SortFunc(states, func(a, b presenceState) int {
+		return strings.Compare(a.Address.String(), b.Address.String())
+	})
+
+	return states
+}
+
+func (p *PresenceTracker) publish(action string, state *presenceState) {
+	eventbus.Publish(presenceEvent, presenceEventData{
+		Action: action,
+		Device: *state,
+	})
+}
+
+func presenceEndpoint(api huma.API, tracker *PresenceTracker) {
+	type PresenceOutput struct {
+		Body []presenceState
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "presence",
+		Method:      http.MethodGet,
+		Path:        "/presence",
+		Summary:     "Presence",
+		Description: "This endpoint fetches the presence state of all recently seen devices. Whenever a device arrives or leaves, it is published to the `/events` stream, with the ***event-name*** as *'presence'*, and with ***event-action*** as either *'arrived'* or *'left'*.",
+		Security:    tokenScopes(ScopeRead),
+	}, func(_ context.Context, input *struct {
+		Present bool `query:"present" doc:"Only fetch devices that are present."`
+	}) (*PresenceOutput, error) {
+		states := tracker.States()
+		if input.Present {
+			states = slices.DeleteFunc(states, func(state presenceState) bool {
+				return !state.Present
+			})
+		}
+
+		return &PresenceOutput{states}, nil
+	})
+}
+
+func (i presenceEventID) String() string {
+	return "presence"
+}
+
+func (i presenceEventID) Value() uint {
+	return uint(i)
+}
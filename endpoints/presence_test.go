@@ -0,0 +1,45 @@
+package endpoints
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+)
+
+func TestPresenceTrackerSweep(t *testing.T) {
+	const timeout = time.Minute
+
+	tests := []struct {
+		name        string
+		state       presenceState
+		want        bool
+		wantPresent bool
+	}{
+		{name: "seen recently", state: presenceState{Present: true, LastSeen: time.Now()}, want: true, wantPresent: true},
+		{name: "hysteresis elapsed", state: presenceState{FirstSeen: time.Now().Add(-timeout / 2), LastSeen: time.Now()}, want: true, wantPresent: true},
+		{name: "left", state: presenceState{Present: true, Connected: true, LastSeen: time.Now().Add(-2 * timeout)}, want: true, wantPresent: false},
+		{name: "absent", state: presenceState{LastSeen: time.Now().Add(-2 * timeout)}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := NewPresenceTracker(PresenceConfig{Timeout: timeout, Hysteresis: timeout / 4})
+			defer tracker.Close()
+
+			address, _ := bluetooth.ParseMAC("AA:BB:CC:DD:EE:FF")
+			test.state.Address = address
+			tracker.devices[address.String()] = &test.state
+
+			tracker.sweep()
+
+			states := tracker.States()
+			if tracked := len(states) > 0; tracked != test.want {
+				t.Fatalf("Device is tracked: %v, want %v", tracked, test.want)
+			}
+			if test.want && states[0].Present != test.wantPresent {
+				t.Errorf("Device is present: %v, want %v", states[0].Present, test.wantPresent)
+			}
+		})
+	}
+}
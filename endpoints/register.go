@@ -5,16 +5,98 @@ import (
 
 	ac "github.com/bluetuith-org/api-native/api/appcapability"
 	bluetooth "github.com/bluetuith-org/api-native/api/bluetooth"
+	"github.com/bluetuith-org/api-native/api/eventbus"
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 )
 
-func Register(router *http.ServeMux, session bluetooth.Session, collection ac.Collection) huma.API {
+// Options describes the configurable behaviour of the API.
+type Options struct {
+	// Info describes the daemon and its platform, and is served by the '/info' endpoint.
+	Info DaemonInfo
+
+	// Tokens, if set, is used to authenticate and authorize all API calls.
+	Tokens *TokenStore
+
+	// Peers, if set, is used to authorize API calls from UNIX socket clients
+	// using their peer credentials, instead of the API tokens.
+	Peers *PeerPolicy
+
+	// Certs, if set, is used to authorize API calls from TLS clients that present
+	// a verified client certificate, instead of the API tokens.
+	Certs *CertPolicy
+
+	// Origins, if set, validates the hosts and origins of API requests,
+	// and rejects state-changing requests that are sent without a CORS preflight.
+	Origins *OriginPolicy
+
+	// EventBufferSize is the number of recent events that are kept for replay.
+	EventBufferSize int
+
+	// Webhooks, if set, delivers events to the configured webhooks.
+	Webhooks *Webhooks
+
+	// MQTT, if set, bridges events and commands to an MQTT broker.
+	MQTT *MQTTBridge
+
+	// AuthRules, if set, are managed through the '/auth/rules' endpoints. The same rules
+	// must be provided to the authorizer of the session, so that they are applied.
+	AuthRules *AuthRules
+
+	// Journal, if set, records all events and mutating API calls.
+	Journal *Journal
+
+	// GRPC, if set, serves the gRPC API.
+	GRPC *GRPCServer
+
+	// JSONRPC, if set, serves the JSON-RPC API.
+	JSONRPC *JSONRPCServer
+
+	// Tracing, if set, records spans for API requests and calls into the Bluetooth session.
+	Tracing *Tracing
+
+	// Presence, if set, derives the presence of devices from the device events.
+	Presence *PresenceTracker
+}
+
+// Register registers the endpoints of the API on the router. The returned function starts the
+// event hub and the services that consume its events, and must be called before the API is served.
+func Register(router *http.ServeMux, session bluetooth.Session, collection ac.Collection, opts Options) (huma.API, func()) {
+	return register(router, session, collection, opts, false)
+}
+
+// OpenAPI returns the description of all endpoints of the API, including the endpoints
+// of the optional features, without starting any of the services of the API.
+func OpenAPI() *huma.OpenAPI {
+	api, _ := register(http.NewServeMux(), nil, ac.MergedCollection(), Options{}, true)
+	return api.OpenAPI()
+}
+
+// register registers the endpoints of the API. If spec is set, the endpoints of all
+// optional features are registered, even if they are not configured.
+func register(router *http.ServeMux, session bluetooth.Session, collection ac.Collection, opts Options, spec bool) (huma.API, func()) {
 	api := humago.New(router, huma.DefaultConfig("My API", "1.0.0"))
+	if opts.Tracing != nil {
+		api.UseMiddleware(opts.Tracing.middleware)
+	}
+	api.UseMiddleware(logOperation)
+	metrics := newMetrics(session)
+	api.UseMiddleware(metrics.middleware)
 	api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
 		ctx.SetHeader("Retry-After", "10")
 		next(ctx)
 	})
+	if opts.Origins != nil {
+		api.UseMiddleware(opts.Origins.middleware(api))
+	}
+	tokenSecuritySchemes(api)
+	access := newAccessControl(opts.Tokens, opts.Peers, opts.Certs)
+	if access.enabled() {
+		api.UseMiddleware(access.middleware(api))
+	}
+	if opts.Journal != nil {
+		api.UseMiddleware(opts.Journal.middleware)
+	}
 	api.OpenAPI().Info = &huma.Info{
 		Title:       "My API",
 		Description: "# Description",
@@ -31,8 +113,22 @@ func Register(router *http.ServeMux, session bluetooth.Session, collection ac.Co
 		Version: "",
 	}
 
+	hub := newEventHub(opts.EventBufferSize)
+	calls := newDispatcher(api, AccessLog(router), access)
 
-	rootEndpoints(api, session)
+	rootEndpoints(api, session, hub)
+	healthEndpoints(api, session, collection, opts.Info, opts.Journal)
+	if opts.Journal != nil || spec {
+		journalEndpoint(api, opts.Journal)
+	}
+	if opts.AuthRules != nil || spec {
+		authRulesEndpoints(api, opts.AuthRules)
+	}
+	if opts.Presence != nil || spec {
+		presenceEndpoint(api, opts.Presence)
+	}
+	metricsEndpoint(api, router, metrics, access)
+	websocketEndpoint(api, router, hub, calls, access)
 	adapterEndpoints(api, session)
 	deviceEndpoints(api, session)
 
@@ -48,5 +144,33 @@ func Register(router *http.ServeMux, session bluetooth.Session, collection ac.Co
 		mediaPlayerEndpoints(api, session)
 	}
 
-	return api
+	start := func() {
+		eventbus.RegisterEventHandlers(hub, eventbus.NilHandler())
+		metrics.start(hub)
+		hub.Listen(logEvent)
+		if opts.Webhooks != nil {
+			opts.Webhooks.start(hub)
+		}
+		if opts.MQTT != nil {
+			opts.MQTT.start(hub, session, collection, calls)
+		}
+		if opts.Journal != nil {
+			opts.Journal.start(hub)
+		}
+		if opts.AuthRules != nil {
+			opts.AuthRules.start(session)
+		}
+		if opts.Presence != nil {
+			opts.Presence.start(hub, session)
+		}
+
+		if opts.GRPC != nil {
+			opts.GRPC.start(hub, calls)
+		}
+		if opts.JSONRPC != nil {
+			opts.JSONRPC.start(hub, calls)
+		}
+	}
+
+	return api, start
 }
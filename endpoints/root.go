@@ -8,8 +8,8 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 )
 
-func rootEndpoints(api huma.API, session bluetooth.Session) {
-	eventsEndpoint(api)
+func rootEndpoints(api huma.API, session bluetooth.Session, hub *eventHub) {
+	eventsEndpoint(api, hub)
 	authEndpoint(api)
 
 	adaptersEndpoint(api, session)
@@ -26,6 +26,7 @@ func adaptersEndpoint(api huma.API, session bluetooth.Session) {
 		Path:        "/adapters",
 		Summary:     "Adapters",
 		Description: "This endpoint fetches all available adapters.",
+		Security:    tokenScopes(ScopeRead),
 	}, func(_ context.Context, input *struct{}) (*AdaptersOutput, error) {
 		return &AdaptersOutput{session.Adapters()}, nil
 	})
@@ -2,48 +2,192 @@ package endpoints
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
 	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
 
 	bluetooth "github.com/bluetuith-org/api-native/api/bluetooth"
-	"github.com/bluetuith-org/api-native/api/eventbus"
 	"github.com/danielgtaylor/huma/v2"
-	"github.com/danielgtaylor/huma/v2/sse"
 )
 
-type eventPublisher struct {
-	sender sse.Sender
-}
+// eventWriteTimeout is the timeout for writing a single event to a client.
+const eventWriteTimeout = 5 * time.Second
 
-func (e *eventPublisher) Publish(id uint, name string, data any) {
-	e.sender(sse.Message{
-		ID:    int(id),
-		Data:  data,
-		Retry: 0,
-	})
+// eventSender sends an event to a client.
+type eventSender func(event hubEvent) error
+
+// eventTypes maps each event name to the type of data it carries.
+func eventTypes() map[string]any {
+	return map[string]any{
+		"auth":         AuthRequestEvent{},
+		"adapter":      bluetooth.AdapterEvent(),
+		"error":        bluetooth.ErrorEvent(),
+		"device":       bluetooth.DeviceEvent(),
+		"mediaplayer":  bluetooth.MediaEvent(),
+		"filetransfer": bluetooth.FileTransferEvent(),
+		"presence":     presenceEventData{},
+		"gap":          eventGap{},
+	}
 }
 
-func eventsEndpoint(api huma.API) {
-	newPublisher := func(sender sse.Sender) (*eventPublisher, eventbus.EventSubscriber) {
-		eh := eventbus.NilHandler()
-		return &eventPublisher{sender}, eh
+func eventsEndpoint(api huma.API, hub *eventHub) {
+	type EventsInput struct {
+		EventFilterInput
+		LastEventID uint `header:"Last-Event-ID" doc:"The ID of the last event received by the client. If set, all events after it are replayed, provided they are still buffered and the ID is from the current run of the daemon."`
+		Agent       bool "query:\"agent\" doc:\"Register the client as an authorization agent, which answers the `auth` events, for as long as it is connected (if `auth` events are not filtered out). If no agent is registered, authorization requests are rejected immediately (unless the daemon is configured otherwise), and an `error` event is published with the reason.\""
 	}
 
-	sse.Register(api, huma.Operation{
+	registerEventStream(api, huma.Operation{
 		OperationID: "events",
 		Method:      http.MethodGet,
 		Path:        "/events",
 		Summary:     "Events",
-	}, map[string]any{
-		"auth":         authRequestEvent{},
-		"adapter":      bluetooth.AdapterEvent(),
-		"error":        bluetooth.ErrorEvent(),
-		"device":       bluetooth.DeviceEvent(),
-		"mediaplayer":  bluetooth.MediaEvent(),
-		"filetransfer": bluetooth.FileTransferEvent(),
-	}, func(ctx context.Context, input *struct{}, send sse.Sender) {
-		eventbus.RegisterEventHandlers(newPublisher(send))
-		defer eventbus.DisableEvents()
+		Description: "This endpoint streams all events as Server Sent Events (SSE). The `event` field of each message is set to the event name. Use the **query parameters** to only receive specific events. When reconnecting, send the `Last-Event-ID` header to replay missed events; if some events are no longer available, a `gap` event is sent first, after which the current state should be resynchronized.",
+		Security:    tokenScopes(ScopeRead),
+	}, eventTypes(), func(ctx context.Context, input *EventsInput, send eventSender) {
+		sub := hub.Subscribe(input.LastEventID)
+		defer hub.Unsubscribe(sub)
+
+		filter := input.Filter()
+		if input.Agent && filter.receives("auth") {
+			defer registerAuthAgent()()
+		}
+		sendEvents := func(events []hubEvent) error {
+			for _, event := range events {
+				if !filter.Match(event) {
+					continue
+				}
+
+				if err := send(event); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		if err := sendEvents(sub.Replay()); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case event := <-sub.events:
+				if err := sendEvents(sub.Next(event)); err != nil {
+					return
+				}
+			}
+		}
+	})
+}
+
+// registerEventStream registers an SSE operation, where the name of each sent event
+// is written to the `event` field of the message. The `eventTypeMap` maps from event
+// name to the type of the data that will be sent, and is only used to document the operation.
+func registerEventStream[I any](api huma.API, op huma.Operation, eventTypeMap map[string]any, f func(ctx context.Context, input *I, send eventSender)) {
+	dataSchemas := make([]*huma.Schema, 0, len(eventTypeMap))
+	for _, name := range slices.Sorted(maps.Keys(eventTypeMap)) {
+		data := eventTypeMap[name]
+		dataSchemas = append(dataSchemas, &huma.Schema{
+			Title: "Event " + name,
+			Type:  huma.TypeObject,
+			Properties: map[string]*huma.Schema{
+				"id": {
+					Type:        huma.TypeInteger,
+					Description: "The event ID.",
+				},
+				"event": {
+					Type:        huma.TypeString,
+					Description: "The event name.",
+					Extensions: map[string]any{
+						"const": name,
+					},
+				},
+				"data": api.OpenAPI().Components.Schemas.Schema(reflect.TypeOf(data), true, name),
+			},
+			Required: []string{"data", "event"},
+		})
+	}
+
+	op.Responses = map[string]*huma.Response{
+		"200": {
+			Content: map[string]*huma.MediaType{
+				"text/event-stream": {
+					Schema: &huma.Schema{
+						Title:       "Server Sent Events",
+						Description: "Each oneOf object in the array represents one possible Server Sent Events (SSE) message, serialized as UTF-8 text according to the SSE specification.",
+						Type:        huma.TypeArray,
+						Items: &huma.Schema{
+							Extensions: map[string]any{
+								"oneOf": dataSchemas,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	huma.Register(api, op, func(_ context.Context, input *I) (*huma.StreamResponse, error) {
+		return &huma.StreamResponse{
+			Body: func(ctx huma.Context) {
+				ctx.SetHeader("Content-Type", "text/event-stream")
+				ctx.SetHeader("Cache-Control", "no-cache")
+
+				bw := ctx.BodyWriter()
+				w, ok := bw.(http.ResponseWriter)
+				if !ok {
+					return
+				}
+
+				rc := http.NewResponseController(w)
+				if err := rc.Flush(); err != nil {
+					return
+				}
+
+				send := func(event hubEvent) error {
+					data, err := json.Marshal(event.Data)
+					if err != nil {
+						return err
+					}
+
+					if err := rc.SetWriteDeadline(time.Now().Add(eventWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+						return err
+					}
+
+					msg := make([]byte, 0, len(data)+len(event.Name)+32)
+					if event.ID > 0 {
+						msg = append(msg, "id: "+strconv.FormatUint(uint64(event.ID), 10)+"\n"...)
+					}
+					if event.Name != "" {
+						msg = append(msg, "event: "+event.Name+"\n"...)
+					}
+					msg = append(msg, "data: "...)
+					msg = append(msg, data...)
+					msg = append(msg, "\n\n"...)
+
+					if _, err := bw.Write(msg); err != nil {
+						return err
+					}
+
+					if err := rc.Flush(); err != nil {
+						return fmt.Errorf("unable to flush: %w", err)
+					}
+
+					return nil
+				}
 
-		<-ctx.Done()
+				f(ctx.Context(), input, send)
+			},
+		}, nil
 	})
 }
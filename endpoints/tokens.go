@@ -0,0 +1,348 @@
+package endpoints
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// The scopes that can be granted to a token.
+const (
+	ScopeRead      = "read"
+	ScopeControl   = "control"
+	ScopePairing   = "pairing"
+	ScopeFiles     = "files"
+	ScopeAuthReply = "auth-reply"
+)
+
+// TokenScopes lists all scopes that can be granted to a token.
+var TokenScopes = []string{ScopeRead, ScopeControl, ScopePairing, ScopeFiles, ScopeAuthReply}
+
+const (
+	tokenPrefix = "brt_"
+
+	// tokenBearerScheme and tokenQueryScheme are the names of the OpenAPI security schemes,
+	// which describe a token sent in the 'Authorization' header and in the query respectively.
+	tokenBearerScheme = "bearer"
+	tokenQueryScheme  = "accessToken"
+	tokenQueryParam   = "access_token"
+)
+
+// Token describes an API token. Only the hash of the token's secret is stored.
+type Token struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Hash      string    `json:"hash"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TokenStore holds the API tokens in a file, which is reloaded whenever it changes,
+// so that created and revoked tokens take effect without restarting the daemon.
+type TokenStore struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	tokens  []Token
+}
+
+// OpenTokenStore opens the token file at path. If the file does not exist, the store is empty.
+func OpenTokenStore(path string) (*TokenStore, error) {
+	s := &TokenStore{path: path}
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Tokens returns all tokens in the store.
+func (s *TokenStore) Tokens() ([]Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+
+	return slices.Clone(s.tokens), nil
+}
+
+// Create adds a new token with the provided name and scopes, and returns the token's secret,
+// which is only available at this point.
+func (s *TokenStore) Create(name string, scopes []string) (string, Token, error) {
+	for _, scope := range scopes {
+		if !slices.Contains(TokenScopes, scope) {
+			return "", Token{}, fmt.Errorf("Invalid scope '%s', must be one of: %s", scope, strings.Join(TokenScopes, ", "))
+		}
+	}
+	if len(scopes) == 0 {
+		return "", Token{}, errors.New("At least one scope must be specified.")
+	}
+
+	var id [4]byte
+	var key [32]byte
+	for _, b := range [][]byte{id[:], key[:]} {
+		if _, err := rand.Read(b); err != nil {
+			return "", Token{}, err
+		}
+	}
+
+	value := tokenPrefix + base64.RawURLEncoding.EncodeToString(key[:])
+	token := Token{
+		ID:        hex.EncodeToString(id[:]),
+		Name:      name,
+		Hash:      tokenHash(value),
+		Scopes:    slices.Compact(slices.Sorted(slices.Values(scopes))),
+		CreatedAt: time.Now(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reload(); err != nil {
+		return "", Token{}, err
+	}
+
+	if err := s.save(append(slices.Clone(s.tokens), token)); err != nil {
+		return "", Token{}, err
+	}
+
+	return value, token, nil
+}
+
+// Revoke removes the token with the provided ID.
+func (s *TokenStore) Revoke(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reload(); err != nil {
+		return err
+	}
+
+	tokens := slices.DeleteFunc(slices.Clone(s.tokens), func(token Token) bool {
+		return token.ID == id
+	})
+	if len(tokens) == len(s.tokens) {
+		return fmt.Errorf("Token '%s' not found.", id)
+	}
+
+	return s.save(tokens)
+}
+
+// lookup returns the token whose secret is value.
+func (s *TokenStore) lookup(value string) (Token, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reload(); err != nil {
+		return Token{}, false
+	}
+
+	hash := tokenHash(value)
+	for _, token := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(token.Hash), []byte(hash)) == 1 {
+			return token, true
+		}
+	}
+
+	return Token{}, false
+}
+
+// authorize authenticates the token sent in the 'Authorization' header or in the query,
+// and checks whether it has been granted the scope. If scope is empty, any valid token
+// is accepted. If the token is not authorized, the status and a description of the error is returned.
+func (s *TokenStore) authorize(header, query, scope string) (int, string) {
+	value, ok := requestToken(header, query)
+	if !ok {
+		return http.StatusUnauthorized, "A bearer token is required."
+	}
+
+	token, ok := s.lookup(value)
+	if !ok {
+		return http.StatusUnauthorized, "The token is invalid or has been revoked."
+	}
+
+	if scope != "" && !token.HasScope(scope) {
+		return http.StatusForbidden, fmt.Sprintf("The token does not have the '%s' scope.", scope)
+	}
+
+	return http.StatusOK, ""
+}
+
+// HasScope reports whether the token has been granted the scope.
+func (t Token) HasScope(scope string) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+func (s *TokenStore) reload() error {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			s.tokens, s.modTime, s.size = nil, time.Time{}, 0
+			return nil
+		}
+
+		return fmt.Errorf("Cannot read the token file '%s': %w", s.path, err)
+	}
+
+	if info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("Cannot read the token file '%s': %w", s.path, err)
+	}
+
+	var tokens []Token
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return fmt.Errorf("Cannot parse the token file '%s': %w", s.path, err)
+	}
+
+	s.tokens, s.modTime, s.size = tokens, info.ModTime(), info.Size()
+
+	return nil
+}
+
+// save atomically replaces the token file with the tokens.
+func (s *TokenStore) save(tokens []Token) error {
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("Cannot write the token file '%s': %w", s.path, err)
+	}
+
+	return s.reload()
+}
+
+// writeFileAtomic replaces the file at path with the data, by writing the data to a
+// temporary file first. The directory of the file is created if it does not exist.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), path)
+}
+
+// tokenScopes returns the security requirements of an operation that requires a token with
+// the provided scope. If no scope is provided, the operation does not require a token.
+func tokenScopes(scope string) []map[string][]string {
+	if scope == "" {
+		return []map[string][]string{}
+	}
+
+	return []map[string][]string{
+		{tokenBearerScheme: {scope}},
+		{tokenQueryScheme: {scope}},
+	}
+}
+
+// tokenSecuritySchemes declares the security schemes and the default security requirement
+// in the OpenAPI specification.
+func tokenSecuritySchemes(api huma.API) {
+	oapi := api.OpenAPI()
+	if oapi.Components.SecuritySchemes == nil {
+		oapi.Components.SecuritySchemes = make(map[string]*huma.SecurityScheme)
+	}
+
+	description := "API tokens are created using the `bluerestd token create` command. " +
+		"Each operation requires a token with a specific scope (one of `" + strings.Join(TokenScopes, "`, `") + "`). " +
+		"Operations that only change state if query parameters are provided, require the `read` scope otherwise."
+
+	oapi.Components.SecuritySchemes[tokenBearerScheme] = &huma.SecurityScheme{
+		Type:        "http",
+		Scheme:      "bearer",
+		Description: description,
+	}
+	oapi.Components.SecuritySchemes[tokenQueryScheme] = &huma.SecurityScheme{
+		Type:        "apiKey",
+		In:          "query",
+		Name:        tokenQueryParam,
+		Description: "The API token, sent as a query parameter, for clients that cannot set headers (for example, browser-based SSE and WebSocket clients).",
+	}
+	oapi.Security = []map[string][]string{
+		{tokenBearerScheme: {}},
+		{tokenQueryScheme: {}},
+	}
+}
+
+// operationScope returns the scope that is required to call the operation in the context.
+// If the operation is public, no token is required.
+func operationScope(api huma.API, ctx huma.Context) (string, bool) {
+	op := ctx.Operation()
+
+	security := op.Security
+	if security == nil {
+		security = api.OpenAPI().Security
+	}
+	if len(security) == 0 {
+		return "", true
+	}
+
+	var scope string
+	for _, requirement := range security {
+		if scopes, ok := requirement[tokenBearerScheme]; ok && len(scopes) > 0 {
+			scope = scopes[0]
+		}
+	}
+
+	if op.Metadata[operationMutating] == operationMutatingQuery && !isMutating(ctx) {
+		scope = ScopeRead
+	}
+
+	return scope, false
+}
+
+// requestToken returns the token sent in the 'Authorization' header, or in the query.
+func requestToken(header, query string) (string, bool) {
+	if value, ok := strings.CutPrefix(header, "Bearer "); ok && value != "" {
+		return strings.TrimSpace(value), true
+	}
+
+	if query != "" {
+		return query, true
+	}
+
+	return "", false
+}
+
+func tokenHash(value string) string {
+	hash := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,223 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/bluetuith-org/api-native/api/bluetooth"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/puzpuzpuz/xsync/v3"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracingShutdownTimeout = 5 * time.Second
+
+// tracer creates all spans of the API. Spans are only recorded if tracing is enabled.
+var tracer = otel.Tracer("github.com/bluetuith-org/daemon/endpoints")
+
+// tracedCalls holds the span contexts of the ongoing native calls, keyed by the device address.
+// Authorization requests that are sent during a call (for example, while pairing) are
+// recorded as child spans of the call, so that the time spent waiting for a reply is visible.
+var tracedCalls = xsync.NewMapOf[string, trace.SpanContext]()
+
+// TracingConfig describes where the spans are exported to.
+type TracingConfig struct {
+	// OTLPEndpoint, if set, is the URL of an OTLP/HTTP collector (for example, 'http://localhost:4318').
+	OTLPEndpoint string
+
+	// File, if set, is the path of a file to which the spans are appended as JSON.
+	File string
+}
+
+// Tracing records OpenTelemetry spans for HTTP requests, API operations, the calls
+// into the Bluetooth session and the authorization requests.
+type Tracing struct {
+	provider *sdktrace.TracerProvider
+	file     *os.File
+}
+
+// NewTracing sets up the exporters described by the configuration, and installs
+// the tracer provider globally.
+func NewTracing(config TracingConfig) (*Tracing, error) {
+	if config.OTLPEndpoint == "" && config.File == "" {
+		return nil, errors.New("Either an OTLP endpoint or a trace file must be specified.")
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName("bluerestd")))
+	if err != nil {
+		return nil, fmt.Errorf("Cannot create the tracing resource: %w", err)
+	}
+
+	t := &Tracing{}
+	opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+
+	if config.OTLPEndpoint != "" {
+		exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(config.OTLPEndpoint))
+		if err != nil {
+			return nil, fmt.Errorf("Cannot create the OTLP exporter for '%s': %w", config.OTLPEndpoint, err)
+		}
+
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	if config.File != "" {
+		file, err := os.OpenFile(config.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot open the trace file '%s': %w", config.File, err)
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("Cannot create the file exporter for '%s': %w", config.File, err)
+		}
+
+		t.file = file
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	t.provider = sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(t.provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	return t, nil
+}
+
+// Handler wraps the HTTP handler, so that a span is recorded for each request.
+// Trace contexts that are sent by clients are continued.
+func (t *Tracing) Handler(handler http.Handler) http.Handler {
+	return otelhttp.NewHandler(handler, "bluerestd",
+		otelhttp.WithTracerProvider(t.provider),
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Path
+		}),
+	)
+}
+
+// Close exports all remaining spans, and stops the exporters.
+func (t *Tracing) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	err := t.provider.Shutdown(ctx)
+	if t.file != nil {
+		err = errors.Join(err, t.file.Close())
+	}
+
+	return err
+}
+
+// middleware records a span for each API operation, named after its operation ID.
+func (t *Tracing) middleware(ctx huma.Context, next func(huma.Context)) {
+	op := ctx.Operation()
+
+	spanCtx, span := tracer.Start(ctx.Context(), op.OperationID,
+		trace.WithAttributes(
+			semconv.HTTPRoute(op.Path),
+			attribute.String("bluerestd.operation", op.OperationID),
+		),
+	)
+	defer span.End()
+
+	if address := ctx.Param("address"); address != "" {
+		span.SetAttributes(attribute.String("bluetooth.address", address))
+	}
+
+	next(huma.WithContext(ctx, spanCtx))
+
+	status := ctx.Status()
+	span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+}
+
+// sessionCall runs a call into the Bluetooth session within a span that is named after the call,
+// and logs and counts the error of the call, if any. Authorization requests for the device that are
+// sent while the call is ongoing are recorded as child spans of the call.
+func sessionCall(ctx context.Context, name string, address bluetooth.MacAddress, call func() error) error {
+	_, span := tracer.Start(ctx, name, trace.WithAttributes(
+		attribute.String("bluetooth.address", address.String()),
+	))
+	defer span.End()
+
+	key := address.String()
+	spanContext := span.SpanContext()
+	if spanContext.IsValid() {
+		tracedCalls.Store(key, spanContext)
+		defer tracedCalls.Compute(key, func(current trace.SpanContext, loaded bool) (trace.SpanContext, bool) {
+			return current, !loaded || current.Equal(spanContext)
+		})
+	}
+
+	err := call()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		slog.ErrorContext(ctx, "Native call failed", "call", name, "address", key, "error", err)
+		recordCallError(ctx)
+	}
+
+	return err
+}
+
+// traceAuthRequest records a span for an authorization request, which ends
+// when the returned function is called with the outcome of the request.
+func traceAuthRequest(data AuthRequestEvent) func(action string, reply authEventReply) {
+	ctx := context.Background()
+	attrs := []attribute.KeyValue{
+		attribute.Int64("bluerestd.auth.id", data.ID),
+		attribute.String("bluerestd.auth.type", data.AuthType),
+	}
+
+	if data.PairingParams != nil {
+		address := data.PairingParams.Address.String()
+		if parent, ok := tracedCalls.Load(address); ok {
+			ctx = trace.ContextWithSpanContext(ctx, parent)
+		}
+
+		attrs = append(attrs,
+			attribute.String("bluerestd.auth.pairing_type", data.PairingParams.PairingType),
+			attribute.String("bluetooth.address", address),
+		)
+	}
+
+	_, span := tracer.Start(ctx, "auth "+data.AuthType, trace.WithAttributes(attrs...))
+
+	return func(action string, reply authEventReply) {
+		switch {
+		case action == "expired":
+			span.SetAttributes(attribute.String("bluerestd.auth.reply", "timeout"))
+			span.SetStatus(codes.Error, "The authorization request timed out.")
+
+		case action == "cancelled":
+			span.SetAttributes(attribute.String("bluerestd.auth.reply", "cancelled"))
+
+		case reply.reply:
+			span.SetAttributes(attribute.String("bluerestd.auth.reply", "yes"))
+
+		default:
+			span.SetAttributes(attribute.String("bluerestd.auth.reply", "no"))
+		}
+
+		span.End()
+	}
+}
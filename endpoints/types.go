@@ -24,3 +24,35 @@ func (a *AddressInput) Resolve(_ huma.Context) []error {
 
 	return nil
 }
+
+const (
+	// operationMutating is the operation metadata key that marks an operation as
+	// one that changes the state of an adapter or device.
+	operationMutating = "mutating"
+
+	// operationMutatingQuery is the operationMutating metadata value for operations
+	// which only change state if query parameters are provided.
+	operationMutatingQuery = "query"
+)
+
+// isMutating reports whether the operation called in the context changes any state.
+func isMutating(ctx huma.Context) bool {
+	op := ctx.Operation()
+	if op == nil {
+		return false
+	}
+
+	switch op.Metadata[operationMutating] {
+	case true:
+		return true
+
+	case operationMutatingQuery:
+		u := ctx.URL()
+		query := u.Query()
+		query.Del(tokenQueryParam)
+
+		return len(query) > 0
+	}
+
+	return false
+}
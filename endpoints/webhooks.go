@@ -0,0 +1,399 @@
+package endpoints
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultWebhookAttempts is the default number of delivery attempts for a webhook event.
+	DefaultWebhookAttempts = 8
+
+	webhookQueueSize  = 256
+	webhookTempPrefix = ".queue-"
+	webhookTimeout    = 10 * time.Second
+	webhookMinBackoff = 1 * time.Second
+	webhookMaxBackoff = 5 * time.Minute
+)
+
+// Webhook describes a URL that receives events as POST requests.
+type Webhook struct {
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret,omitempty"`
+	Events      []string `json:"events,omitempty"`
+	Actions     []string `json:"actions,omitempty"`
+	Addresses   []string `json:"addresses,omitempty"`
+	MaxAttempts int      `json:"max_attempts,omitempty"`
+
+	filter eventFilter
+}
+
+// Webhooks delivers events to a set of webhooks. Each webhook has its own queue, from which
+// events are delivered one at a time in the order they were published. Deliveries that fail
+// are retried with an exponential backoff, and the queue of each webhook is persisted to the
+// queue directory until its deliveries are either delivered or all attempts are exhausted.
+type Webhooks struct {
+	queues   []*webhookQueue
+	queueDir string
+	client   *http.Client
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	workers sync.WaitGroup
+}
+
+// webhookQueue holds the pending deliveries of a webhook. At most webhookQueueSize
+// deliveries are queued, and further events are dropped until the queue drains.
+type webhookQueue struct {
+	hook *Webhook
+	path string
+
+	mu         sync.Mutex
+	deliveries []*webhookDelivery
+	wake       chan struct{}
+}
+
+// webhookPayload is the body of each webhook request.
+type webhookPayload struct {
+	ID        uint      `json:"id"`
+	Event     string    `json:"event"`
+	Data      any       `json:"data"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// webhookDelivery is a pending delivery of an event to a webhook.
+type webhookDelivery struct {
+	ID          string          `json:"id"`
+	Event       string          `json:"event"`
+	Payload     json.RawMessage `json:"payload"`
+	Attempts    int             `json:"attempts"`
+	NextAttempt time.Time       `json:"next_attempt"`
+}
+
+// NewWebhooks loads the webhooks from the JSON configuration file at configPath,
+// which contains an array of webhook definitions. Pending deliveries are stored
+// in queueDir, which is created if it does not exist.
+func NewWebhooks(configPath, queueDir string) (*Webhooks, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read webhook configuration: %w", err)
+	}
+
+	var hooks []*Webhook
+	if err := json.Unmarshal(data, &hooks); err != nil {
+		return nil, fmt.Errorf("Cannot parse webhook configuration: %w", err)
+	}
+
+	w := &Webhooks{
+		queues:   make([]*webhookQueue, 0, len(hooks)),
+		queueDir: queueDir,
+		client:   &http.Client{Timeout: webhookTimeout},
+	}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+
+	urls := make(map[string]struct{}, len(hooks))
+	for _, hook := range hooks {
+		if u, err := url.Parse(hook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, fmt.Errorf("Invalid webhook URL '%s'", hook.URL)
+		}
+
+		if _, ok := urls[hook.URL]; ok {
+			return nil, fmt.Errorf("Duplicate webhook URL '%s'", hook.URL)
+		}
+		urls[hook.URL] = struct{}{}
+
+		if hook.filter, err = newEventFilter(hook.Events, hook.Addresses, hook.Actions); err != nil {
+			return nil, fmt.Errorf("Invalid webhook filter for '%s': %w", hook.URL, err)
+		}
+
+		if hook.MaxAttempts <= 0 {
+			hook.MaxAttempts = DefaultWebhookAttempts
+		}
+
+		sum := sha256.Sum256([]byte(hook.URL))
+		w.queues = append(w.queues, &webhookQueue{
+			hook: hook,
+			path: filepath.Join(queueDir, hex.EncodeToString(sum[:8])+".json"),
+			wake: make(chan struct{}, 1),
+		})
+	}
+
+	if err := os.MkdirAll(queueDir, 0o700); err != nil {
+		return nil, fmt.Errorf("Cannot create webhook queue directory: %w", err)
+	}
+
+	return w, nil
+}
+
+// Close stops delivering events, and waits until the deliveries in progress are stopped.
+// The pending deliveries are kept in the queue directory, and are resumed on the next start.
+func (w *Webhooks) Close() {
+	w.cancel()
+	w.workers.Wait()
+}
+
+// start resumes the pending deliveries of each webhook, and delivers all subsequent events from the hub.
+// The queues of webhooks that are no longer configured, and temporary files that were left behind
+// if the daemon stopped while storing a queue, are removed.
+func (w *Webhooks) start(hub *eventHub) {
+	paths := make([]string, 0, len(w.queues))
+	for _, queue := range w.queues {
+		queue.load()
+		paths = append(paths, queue.path)
+
+		w.workers.Add(1)
+		go queue.run(w)
+	}
+
+	if entries, err := os.ReadDir(w.queueDir); err == nil {
+		for _, entry := range entries {
+			path := filepath.Join(w.queueDir, entry.Name())
+			if entry.IsDir() || slices.Contains(paths, path) {
+				continue
+			}
+
+			if filepath.Ext(path) == ".json" || strings.HasPrefix(entry.Name(), webhookTempPrefix) {
+				os.Remove(path)
+			}
+		}
+	}
+
+	hub.Listen(w.publish)
+}
+
+// publish queues a delivery of the event for every webhook that it matches.
+func (w *Webhooks) publish(event hubEvent) {
+	var payload []byte
+
+	for _, queue := range w.queues {
+		if !queue.hook.filter.Match(event) {
+			continue
+		}
+
+		if payload == nil {
+			var err error
+			payload, err = json.Marshal(webhookPayload{
+				ID:        event.ID,
+				Event:     event.Name,
+				Data:      event.Data,
+				Timestamp: time.Now(),
+			})
+			if err != nil {
+				return
+			}
+		}
+
+		queue.push(&webhookDelivery{
+			ID:          uuid.NewString(),
+			Event:       event.Name,
+			Payload:     payload,
+			NextAttempt: time.Now(),
+		})
+	}
+}
+
+// send sends the delivery to the webhook. The payload is signed using HMAC-SHA256
+// with the webhook's secret, and the hex-encoded signature is sent in the
+// 'X-Bluerestd-Signature' header as 'sha256=<signature>'.
+func (w *Webhooks) send(hook *Webhook, delivery *webhookDelivery) error {
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodPost, hook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "bluerestd")
+	req.Header.Set("X-Bluerestd-Event", delivery.Event)
+	req.Header.Set("X-Bluerestd-Delivery", delivery.ID)
+	req.Header.Set("X-Bluerestd-Attempt", strconv.Itoa(delivery.Attempts))
+	if hook.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(hook.Secret))
+		mac.Write(delivery.Payload)
+		req.Header.Set("X-Bluerestd-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Webhook '%s' responded with status %d", hook.URL, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// push appends the delivery to the queue, unless the queue is full.
+func (q *webhookQueue) push(delivery *webhookDelivery) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.deliveries) >= webhookQueueSize {
+		slog.Warn("Webhook queue is full, dropping event", "url", q.hook.URL, "event", delivery.Event)
+		return
+	}
+
+	q.deliveries = append(q.deliveries, delivery)
+	q.save()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run delivers the queued deliveries in order, until each of them either
+// succeeds or all of its attempts are exhausted, or until the webhooks are closed.
+// An attempt that is interrupted by closing the webhooks is not counted.
+func (q *webhookQueue) run(w *Webhooks) {
+	defer w.workers.Done()
+
+	for {
+		q.mu.Lock()
+		if len(q.deliveries) == 0 {
+			q.mu.Unlock()
+
+			select {
+			case <-q.wake:
+			case <-w.ctx.Done():
+				return
+			}
+
+			continue
+		}
+		delivery := *q.deliveries[0]
+		q.mu.Unlock()
+
+		var err error
+		for delivery.Attempts < q.hook.MaxAttempts {
+			timer := time.NewTimer(time.Until(delivery.NextAttempt))
+			select {
+			case <-timer.C:
+			case <-w.ctx.Done():
+				timer.Stop()
+				return
+			}
+
+			delivery.Attempts++
+			if err = w.send(q.hook, &delivery); err == nil {
+				break
+			}
+			if w.ctx.Err() != nil {
+				return
+			}
+
+			delivery.NextAttempt = time.Now().Add(webhookBackoff(delivery.Attempts))
+			q.update(delivery)
+		}
+		if err != nil {
+			slog.Warn("Webhook delivery failed, all attempts are exhausted", "url", q.hook.URL, "event", delivery.Event, "error", err)
+		}
+
+		q.mu.Lock()
+		q.deliveries = q.deliveries[1:]
+		q.save()
+		q.mu.Unlock()
+	}
+}
+
+// update replaces the delivery at the head of the queue after a failed attempt.
+func (q *webhookQueue) update(delivery webhookDelivery) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	*q.deliveries[0] = delivery
+	q.save()
+}
+
+// load reads the pending deliveries from the queue file.
+func (q *webhookQueue) load() {
+	data, err := os.ReadFile(q.path)
+	if err != nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := json.Unmarshal(data, &q.deliveries); err != nil {
+		slog.Warn("Cannot read the webhook queue, pending deliveries are discarded", "url", q.hook.URL, "error", err)
+		q.deliveries = nil
+		os.Remove(q.path)
+	}
+}
+
+// save stores the queue, and logs the error if it cannot be stored. The deliveries are still
+// attempted, but they are lost if the daemon stops before they are delivered.
+// It must be called with the lock held.
+func (q *webhookQueue) save() {
+	if err := q.store(); err != nil {
+		slog.Error("Cannot store the webhook queue, pending deliveries are lost if the daemon stops", "url", q.hook.URL, "path", q.path, "error", err)
+	}
+}
+
+// store atomically writes the pending deliveries to the queue file, or removes the
+// file if there are none. The temporary file is removed if it cannot be written.
+// It must be called with the lock held.
+func (q *webhookQueue) store() error {
+	if len(q.deliveries) == 0 {
+		if err := os.Remove(q.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		return nil
+	}
+
+	data, err := json.Marshal(q.deliveries)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(q.path), webhookTempPrefix+"*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(data)
+	err = errors.Join(err, tmp.Close())
+	if err == nil {
+		err = os.Rename(tmp.Name(), q.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+
+	return err
+}
+
+// webhookBackoff returns the delay before the next delivery attempt, which doubles
+// with each attempt up to a maximum, with some added jitter.
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookMaxBackoff
+	if attempts < 16 {
+		backoff = min(webhookMinBackoff<<(attempts-1), webhookMaxBackoff)
+	}
+
+	return backoff + rand.N(backoff/4+1)
+}
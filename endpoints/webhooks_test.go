@@ -0,0 +1,285 @@
+package endpoints
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// webhookRequest is a request received by a test webhook.
+type webhookRequest struct {
+	header  http.Header
+	body    []byte
+	payload webhookPayload
+}
+
+// newTestWebhooks starts webhooks that deliver all events to a test server, which
+// responds to each request with the status returned by respond.
+func newTestWebhooks(t *testing.T, hook Webhook, respond func(r webhookRequest) int) (*Webhooks, <-chan webhookRequest) {
+	t.Helper()
+
+	requests := make(chan webhookRequest, 16)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Cannot read webhook request: %v", err)
+		}
+
+		req := webhookRequest{header: r.Header, body: body}
+		if err := json.Unmarshal(body, &req.payload); err != nil {
+			t.Errorf("Cannot parse webhook payload: %v", err)
+		}
+
+		w.WriteHeader(respond(req))
+		requests <- req
+	}))
+	t.Cleanup(server.Close)
+
+	hook.URL = server.URL
+
+	dir := t.TempDir()
+	config, err := json.Marshal([]Webhook{hook})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configPath := filepath.Join(dir, "webhooks.json")
+	if err := os.WriteFile(configPath, config, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	webhooks, err := NewWebhooks(configPath, filepath.Join(dir, "queue"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	webhooks.start(newEventHub(16))
+	t.Cleanup(webhooks.Close)
+
+	return webhooks, requests
+}
+
+func receiveWebhook(t *testing.T, requests <-chan webhookRequest) webhookRequest {
+	t.Helper()
+
+	select {
+	case req := <-requests:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatal("Webhook request was not received")
+	}
+
+	return webhookRequest{}
+}
+
+func testAuthEvent(id uint) hubEvent {
+	return hubEvent{ID: id, Name: "auth", Data: AuthRequestEvent{ID: int64(id), Action: "requested"}}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	const secret = "secret"
+
+	webhooks, requests := newTestWebhooks(t, Webhook{Secret: secret}, func(webhookRequest) int {
+		return http.StatusOK
+	})
+	webhooks.publish(testAuthEvent(1))
+
+	req := receiveWebhook(t, requests)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(req.body)
+	if want, got := "sha256="+hex.EncodeToString(mac.Sum(nil)), req.header.Get("X-Bluerestd-Signature"); got != want {
+		t.Errorf("Signature is %q, want %q", got, want)
+	}
+
+	if got := req.header.Get("X-Bluerestd-Event"); got != "auth" {
+		t.Errorf("Event header is %q, want %q", got, "auth")
+	}
+	if req.payload.ID != 1 || req.payload.Event != "auth" {
+		t.Errorf("Payload is for event %d '%s', want event 1 'auth'", req.payload.ID, req.payload.Event)
+	}
+}
+
+func TestWebhookUnsigned(t *testing.T) {
+	webhooks, requests := newTestWebhooks(t, Webhook{}, func(webhookRequest) int {
+		return http.StatusNoContent
+	})
+	webhooks.publish(testAuthEvent(1))
+
+	if got := receiveWebhook(t, requests).header.Get("X-Bluerestd-Signature"); got != "" {
+		t.Errorf("Signature is %q, want none without a secret", got)
+	}
+}
+
+func TestWebhookRetry(t *testing.T) {
+	var mu sync.Mutex
+	failures := 1
+
+	webhooks, requests := newTestWebhooks(t, Webhook{}, func(webhookRequest) int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if failures > 0 {
+			failures--
+			return http.StatusInternalServerError
+		}
+
+		return http.StatusOK
+	})
+	webhooks.publish(testAuthEvent(1))
+
+	first, retry := receiveWebhook(t, requests), receiveWebhook(t, requests)
+
+	if got := first.header.Get("X-Bluerestd-Attempt"); got != "1" {
+		t.Errorf("First attempt header is %q, want %q", got, "1")
+	}
+	if got := retry.header.Get("X-Bluerestd-Attempt"); got != "2" {
+		t.Errorf("Retry attempt header is %q, want %q", got, "2")
+	}
+	if a, b := first.header.Get("X-Bluerestd-Delivery"), retry.header.Get("X-Bluerestd-Delivery"); a == "" || a != b {
+		t.Errorf("Retry delivery ID is %q, want %q", b, a)
+	}
+	if string(first.body) != string(retry.body) {
+		t.Errorf("Retry payload is %s, want %s", retry.body, first.body)
+	}
+}
+
+func TestWebhookMaxAttempts(t *testing.T) {
+	webhooks, requests := newTestWebhooks(t, Webhook{MaxAttempts: 1}, func(r webhookRequest) int {
+		if r.payload.ID == 1 {
+			return http.StatusInternalServerError
+		}
+
+		return http.StatusOK
+	})
+	webhooks.publish(testAuthEvent(1))
+	webhooks.publish(testAuthEvent(2))
+
+	for _, want := range []uint{1, 2} {
+		if got := receiveWebhook(t, requests).payload.ID; got != want {
+			t.Fatalf("Received event %d, want %d", got, want)
+		}
+	}
+}
+
+func TestWebhookOrder(t *testing.T) {
+	var mu sync.Mutex
+	failures := 1
+
+	// The first delivery fails once, and the subsequent events must wait for its retry.
+	webhooks, requests := newTestWebhooks(t, Webhook{}, func(webhookRequest) int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if failures > 0 {
+			failures--
+			return http.StatusServiceUnavailable
+		}
+
+		return http.StatusOK
+	})
+
+	const count = 5
+	for id := uint(1); id <= count; id++ {
+		webhooks.publish(testAuthEvent(id))
+	}
+
+	want := []uint{1, 1, 2, 3, 4, 5}
+	for i := range want {
+		if got := receiveWebhook(t, requests).payload.ID; got != want[i] {
+			t.Fatalf("Request %d is for event %d, want %d", i+1, got, want[i])
+		}
+	}
+}
+
+func TestWebhookFilter(t *testing.T) {
+	webhooks, requests := newTestWebhooks(t, Webhook{Events: []string{"presence"}}, func(webhookRequest) int {
+		return http.StatusOK
+	})
+	webhooks.publish(testAuthEvent(1))
+	webhooks.publish(hubEvent{ID: 2, Name: "presence", Data: presenceEventData{Action: "arrived"}})
+
+	if got := receiveWebhook(t, requests).payload.ID; got != 2 {
+		t.Errorf("Received event %d, want only the matching event 2", got)
+	}
+}
+
+func TestWebhookQueueResume(t *testing.T) {
+	dir := t.TempDir()
+	queue := &webhookQueue{
+		hook: &Webhook{URL: "http://localhost"},
+		path: filepath.Join(dir, "queue.json"),
+		wake: make(chan struct{}, 1),
+	}
+
+	for id := 1; id <= 3; id++ {
+		queue.push(&webhookDelivery{ID: strconv.Itoa(id), Event: "auth", Payload: json.RawMessage(`{}`)})
+	}
+
+	resumed := &webhookQueue{hook: queue.hook, path: queue.path}
+	resumed.load()
+
+	if len(resumed.deliveries) != 3 {
+		t.Fatalf("Resumed %d deliveries, want 3", len(resumed.deliveries))
+	}
+	for i, delivery := range resumed.deliveries {
+		if want := strconv.Itoa(i + 1); delivery.ID != want {
+			t.Errorf("Delivery %d has ID %q, want %q", i, delivery.ID, want)
+		}
+	}
+}
+
+func TestWebhookClose(t *testing.T) {
+	webhooks, requests := newTestWebhooks(t, Webhook{}, func(webhookRequest) int {
+		return http.StatusInternalServerError
+	})
+	webhooks.publish(testAuthEvent(1))
+
+	receiveWebhook(t, requests)
+	webhooks.Close()
+
+	// The delivery is retried after the backoff, so it is pending when the webhooks are closed.
+	resumed := &webhookQueue{hook: webhooks.queues[0].hook, path: webhooks.queues[0].path}
+	resumed.load()
+
+	if len(resumed.deliveries) != 1 {
+		t.Fatalf("Resumed %d deliveries, want the pending delivery", len(resumed.deliveries))
+	}
+}
+
+func TestWebhookQueueStoreFailure(t *testing.T) {
+	dir := t.TempDir()
+	queue := &webhookQueue{
+		hook: &Webhook{URL: "http://localhost"},
+		path: filepath.Join(dir, "queue.json"),
+	}
+
+	// The queue file cannot be replaced by a directory.
+	if err := os.Mkdir(queue.path, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	queue.deliveries = []*webhookDelivery{{ID: "1", Event: "auth", Payload: json.RawMessage(`{}`)}}
+	if err := queue.store(); err == nil {
+		t.Fatal("Queue was stored in place of a directory")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != "queue.json" {
+			t.Errorf("Temporary file '%s' was not removed", entry.Name())
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// wsCommand is a command frame sent by a websocket client.
+type wsCommand struct {
+	RequestID string `json:"request_id"`
+	dispatchRequest
+}
+
+// wsFrame is a frame sent to a websocket client, which is either an
+// event (with the same data as the `/events` stream), or a response to a command.
+type wsFrame struct {
+	Type      string `json:"type"`
+	RequestID string `json:"request_id,omitempty"`
+
+	ID    uint   `json:"id,omitempty"`
+	Event string `json:"event,omitempty"`
+
+	Status int `json:"status,omitempty"`
+	Data   any `json:"data,omitempty"`
+	Error  any `json:"error,omitempty"`
+}
+
+// wsForwardedHeaders are the handshake request headers that are forwarded
+// to every operation called by a websocket client.
+var wsForwardedHeaders = []string{"Authorization"}
+
+func websocketEndpoint(api huma.API, router *http.ServeMux, hub *eventHub, calls *dispatcher, access *accessControl) {
+	op := &huma.Operation{
+		OperationID: "websocket",
+		Method:      http.MethodGet,
+		Path:        "/ws",
+		Summary:     "WebSocket",
+		Description: "This endpoint upgrades the connection to a WebSocket, which streams all events and accepts commands.\n\n" +
+			"Events are sent as `{\"type\": \"event\", \"id\": <id>, \"event\": <event-name>, \"data\": <data>}`, where `data` is the same as the data of the corresponding event in the `/events` stream.\n\n" +
+			"Commands are sent as `{\"request_id\": <id>, \"operation\": <operation-id>, \"params\": {<name>: <value>}, \"body\": <body>}`, where `operation` is the ID of any other operation in this specification, " +
+			"`params` holds its path and query parameters, and `body` holds its request body, if any. " +
+			"Each command is answered with `{\"type\": \"response\", \"request_id\": <id>, \"status\": <http-status>, \"data\": <response>, \"error\": <error>}`.",
+		Security: tokenScopes(ScopeRead),
+		Parameters: []*huma.Param{
+			{Name: "type", In: "query", Description: "Only receive events with the specified (comma-separated) event names.", Schema: &huma.Schema{Type: huma.TypeString}},
+			{Name: "address", In: "query", Description: "Only receive events associated with the specified (comma-separated) device or adapter Bluetooth MAC addresses.", Schema: &huma.Schema{Type: huma.TypeString}},
+			{Name: "action", In: "query", Description: "Only receive events with the specified (comma-separated) event actions.", Schema: &huma.Schema{Type: huma.TypeString}},
+			{Name: "last_event_id", In: "query", Description: "The ID of the last event received by the client, after which all buffered events are replayed.", Schema: &huma.Schema{Type: huma.TypeInteger}},
+			{Name: "agent", In: "query", Description: "Register the client as an authorization agent, which answers the `auth` events, for as long as it is connected (if `auth` events are not filtered out).", Schema: &huma.Schema{Type: huma.TypeBoolean}},
+		},
+		Responses: map[string]*huma.Response{
+			"101": {Description: "Switching Protocols"},
+		},
+	}
+	api.OpenAPI().AddOperation(op)
+
+	router.Handle(op.Method+" "+op.Path, access.handler(ScopeRead, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter, err := parseEventFilter(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+
+		var lastEventID uint64
+		if id := r.URL.Query().Get("last_event_id"); id != "" {
+			if lastEventID, err = strconv.ParseUint(id, 10, 0); err != nil {
+				http.Error(w, "Invalid last event ID.", http.StatusUnprocessableEntity)
+				return
+			}
+		}
+
+		var agent bool
+		if value := r.URL.Query().Get("agent"); value != "" {
+			if agent, err = strconv.ParseBool(value); err != nil {
+				http.Error(w, "Invalid agent parameter.", http.StatusUnprocessableEntity)
+				return
+			}
+		}
+
+		// If the origin has already been verified, the connection is accepted from it.
+		conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{InsecureSkipVerify: originVerified(r.Context())})
+		if err != nil {
+			return
+		}
+		defer conn.CloseNow()
+
+		header := http.Header{}
+		for _, name := range wsForwardedHeaders {
+			if value := r.Header.Values(name); value != nil {
+				header[name] = value
+			}
+		}
+		if token := r.URL.Query().Get(tokenQueryParam); token != "" && header.Get("Authorization") == "" {
+			header.Set("Authorization", "Bearer "+token)
+		}
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		go func() {
+			defer cancel()
+
+			for {
+				_, data, err := conn.Read(ctx)
+				if err != nil {
+					return
+				}
+
+				var command wsCommand
+				if err := json.Unmarshal(data, &command); err != nil {
+					wsjson.Write(ctx, conn, wsFrame{
+						Type:   "response",
+						Status: http.StatusBadRequest,
+						Error:  huma.NewError(http.StatusBadRequest, "Invalid command: "+err.Error()),
+					})
+
+					continue
+				}
+
+				go func() {
+					frame := wsFrame{Type: "response", RequestID: command.RequestID}
+
+					resp, err := calls.Call(ctx, header, command.dispatchRequest)
+					if err != nil {
+						frame.Status = http.StatusBadRequest
+						frame.Error = huma.NewError(http.StatusBadRequest, err.Error())
+					} else {
+						frame.Status = resp.Status
+						if resp.Data != nil {
+							frame.Data = resp.Data
+						}
+						if resp.Error != nil {
+							frame.Error = resp.Error
+						}
+					}
+
+					wsjson.Write(ctx, conn, frame)
+				}()
+			}
+		}()
+
+		sub := hub.Subscribe(uint(lastEventID))
+		defer hub.Unsubscribe(sub)
+
+		if agent && filter.receives("auth") {
+			defer registerAuthAgent()()
+		}
+
+		sendEvents := func(events []hubEvent) error {
+			for _, event := range events {
+				if !filter.Match(event) {
+					continue
+				}
+
+				if err := wsjson.Write(ctx, conn, wsFrame{
+					Type:  "event",
+					ID:    event.ID,
+					Event: event.Name,
+					Data:  event.Data,
+				}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		if err := sendEvents(sub.Replay()); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				conn.Close(websocket.StatusNormalClosure, "")
+				return
+
+			case event := <-sub.events:
+				if err := sendEvents(sub.Next(event)); err != nil {
+					return
+				}
+			}
+		}
+	})))
+}
@@ -0,0 +1,1875 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: bluerestd.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AddressRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The Bluetooth MAC address of the adapter or device.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *AddressRequest) Reset() {
+	*x = AddressRequest{}
+	mi := &file_bluerestd_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressRequest) ProtoMessage() {}
+
+func (x *AddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressRequest.ProtoReflect.Descriptor instead.
+func (*AddressRequest) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AddressRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type PropertiesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Properties *structpb.Struct `protobuf:"bytes,1,opt,name=properties,proto3" json:"properties,omitempty"`
+}
+
+func (x *PropertiesResponse) Reset() {
+	*x = PropertiesResponse{}
+	mi := &file_bluerestd_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PropertiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PropertiesResponse) ProtoMessage() {}
+
+func (x *PropertiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PropertiesResponse.ProtoReflect.Descriptor instead.
+func (*PropertiesResponse) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PropertiesResponse) GetProperties() *structpb.Struct {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type AdaptersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Adapters []*structpb.Struct `protobuf:"bytes,1,rep,name=adapters,proto3" json:"adapters,omitempty"`
+}
+
+func (x *AdaptersResponse) Reset() {
+	*x = AdaptersResponse{}
+	mi := &file_bluerestd_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdaptersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdaptersResponse) ProtoMessage() {}
+
+func (x *AdaptersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdaptersResponse.ProtoReflect.Descriptor instead.
+func (*AdaptersResponse) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AdaptersResponse) GetAdapters() []*structpb.Struct {
+	if x != nil {
+		return x.Adapters
+	}
+	return nil
+}
+
+type DevicesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Devices []*structpb.Struct `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+}
+
+func (x *DevicesResponse) Reset() {
+	*x = DevicesResponse{}
+	mi := &file_bluerestd_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DevicesResponse) ProtoMessage() {}
+
+func (x *DevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DevicesResponse.ProtoReflect.Descriptor instead.
+func (*DevicesResponse) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DevicesResponse) GetDevices() []*structpb.Struct {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type AdapterStatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The Bluetooth MAC address of the adapter.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Each state is only changed if it is set.
+	Powered      *bool `protobuf:"varint,2,opt,name=powered,proto3,oneof" json:"powered,omitempty"`
+	Pairable     *bool `protobuf:"varint,3,opt,name=pairable,proto3,oneof" json:"pairable,omitempty"`
+	Discoverable *bool `protobuf:"varint,4,opt,name=discoverable,proto3,oneof" json:"discoverable,omitempty"`
+	Discovery    *bool `protobuf:"varint,5,opt,name=discovery,proto3,oneof" json:"discovery,omitempty"`
+}
+
+func (x *AdapterStatesRequest) Reset() {
+	*x = AdapterStatesRequest{}
+	mi := &file_bluerestd_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdapterStatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdapterStatesRequest) ProtoMessage() {}
+
+func (x *AdapterStatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdapterStatesRequest.ProtoReflect.Descriptor instead.
+func (*AdapterStatesRequest) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AdapterStatesRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *AdapterStatesRequest) GetPowered() bool {
+	if x != nil && x.Powered != nil {
+		return *x.Powered
+	}
+	return false
+}
+
+func (x *AdapterStatesRequest) GetPairable() bool {
+	if x != nil && x.Pairable != nil {
+		return *x.Pairable
+	}
+	return false
+}
+
+func (x *AdapterStatesRequest) GetDiscoverable() bool {
+	if x != nil && x.Discoverable != nil {
+		return *x.Discoverable
+	}
+	return false
+}
+
+func (x *AdapterStatesRequest) GetDiscovery() bool {
+	if x != nil && x.Discovery != nil {
+		return *x.Discovery
+	}
+	return false
+}
+
+type AdapterStatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Each state is either 'enabled' or 'disabled'.
+	Powered      string `protobuf:"bytes,1,opt,name=powered,proto3" json:"powered,omitempty"`
+	Pairable     string `protobuf:"bytes,2,opt,name=pairable,proto3" json:"pairable,omitempty"`
+	Discoverable string `protobuf:"bytes,3,opt,name=discoverable,proto3" json:"discoverable,omitempty"`
+	Discovery    string `protobuf:"bytes,4,opt,name=discovery,proto3" json:"discovery,omitempty"`
+}
+
+func (x *AdapterStatesResponse) Reset() {
+	*x = AdapterStatesResponse{}
+	mi := &file_bluerestd_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdapterStatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdapterStatesResponse) ProtoMessage() {}
+
+func (x *AdapterStatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdapterStatesResponse.ProtoReflect.Descriptor instead.
+func (*AdapterStatesResponse) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AdapterStatesResponse) GetPowered() string {
+	if x != nil {
+		return x.Powered
+	}
+	return ""
+}
+
+func (x *AdapterStatesResponse) GetPairable() string {
+	if x != nil {
+		return x.Pairable
+	}
+	return ""
+}
+
+func (x *AdapterStatesResponse) GetDiscoverable() string {
+	if x != nil {
+		return x.Discoverable
+	}
+	return ""
+}
+
+func (x *AdapterStatesResponse) GetDiscovery() string {
+	if x != nil {
+		return x.Discovery
+	}
+	return ""
+}
+
+type MediaPlayerControlRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The Bluetooth MAC address of the device.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// One of 'play', 'pause', 'next', 'previous', 'fast-forward', 'rewind' or 'stop'.
+	ControlType string `protobuf:"bytes,2,opt,name=control_type,json=controlType,proto3" json:"control_type,omitempty"`
+}
+
+func (x *MediaPlayerControlRequest) Reset() {
+	*x = MediaPlayerControlRequest{}
+	mi := &file_bluerestd_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaPlayerControlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaPlayerControlRequest) ProtoMessage() {}
+
+func (x *MediaPlayerControlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaPlayerControlRequest.ProtoReflect.Descriptor instead.
+func (*MediaPlayerControlRequest) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MediaPlayerControlRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *MediaPlayerControlRequest) GetControlType() string {
+	if x != nil {
+		return x.ControlType
+	}
+	return ""
+}
+
+type NetworkConnectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The Bluetooth MAC address of the device.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Either 'panu' or 'dun'. Defaults to 'panu'.
+	ConnectionType string `protobuf:"bytes,2,opt,name=connection_type,json=connectionType,proto3" json:"connection_type,omitempty"`
+}
+
+func (x *NetworkConnectRequest) Reset() {
+	*x = NetworkConnectRequest{}
+	mi := &file_bluerestd_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkConnectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkConnectRequest) ProtoMessage() {}
+
+func (x *NetworkConnectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkConnectRequest.ProtoReflect.Descriptor instead.
+func (*NetworkConnectRequest) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NetworkConnectRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *NetworkConnectRequest) GetConnectionType() string {
+	if x != nil {
+		return x.ConnectionType
+	}
+	return ""
+}
+
+type FileTransferStartRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The Bluetooth MAC address of the device.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// The full paths of files to be sent.
+	FilePaths []string `protobuf:"bytes,2,rep,name=file_paths,json=filePaths,proto3" json:"file_paths,omitempty"`
+}
+
+func (x *FileTransferStartRequest) Reset() {
+	*x = FileTransferStartRequest{}
+	mi := &file_bluerestd_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileTransferStartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileTransferStartRequest) ProtoMessage() {}
+
+func (x *FileTransferStartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileTransferStartRequest.ProtoReflect.Descriptor instead.
+func (*FileTransferStartRequest) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FileTransferStartRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *FileTransferStartRequest) GetFilePaths() []string {
+	if x != nil {
+		return x.FilePaths
+	}
+	return nil
+}
+
+type FileTransferStartResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueuedFiles []*structpb.Struct `protobuf:"bytes,1,rep,name=queued_files,json=queuedFiles,proto3" json:"queued_files,omitempty"`
+}
+
+func (x *FileTransferStartResponse) Reset() {
+	*x = FileTransferStartResponse{}
+	mi := &file_bluerestd_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileTransferStartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileTransferStartResponse) ProtoMessage() {}
+
+func (x *FileTransferStartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileTransferStartResponse.ProtoReflect.Descriptor instead.
+func (*FileTransferStartResponse) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FileTransferStartResponse) GetQueuedFiles() []*structpb.Struct {
+	if x != nil {
+		return x.QueuedFiles
+	}
+	return nil
+}
+
+type AuthIDRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The authorization ID provided by the 'auth' event.
+	AuthId int64 `protobuf:"varint,1,opt,name=auth_id,json=authId,proto3" json:"auth_id,omitempty"`
+}
+
+func (x *AuthIDRequest) Reset() {
+	*x = AuthIDRequest{}
+	mi := &file_bluerestd_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthIDRequest) ProtoMessage() {}
+
+func (x *AuthIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthIDRequest.ProtoReflect.Descriptor instead.
+func (*AuthIDRequest) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AuthIDRequest) GetAuthId() int64 {
+	if x != nil {
+		return x.AuthId
+	}
+	return 0
+}
+
+type AuthRequestsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Requests []*structpb.Struct `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+}
+
+func (x *AuthRequestsResponse) Reset() {
+	*x = AuthRequestsResponse{}
+	mi := &file_bluerestd_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthRequestsResponse) ProtoMessage() {}
+
+func (x *AuthRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthRequestsResponse.ProtoReflect.Descriptor instead.
+func (*AuthRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AuthRequestsResponse) GetRequests() []*structpb.Struct {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type AuthRequestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Request *structpb.Struct `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+}
+
+func (x *AuthRequestResponse) Reset() {
+	*x = AuthRequestResponse{}
+	mi := &file_bluerestd_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthRequestResponse) ProtoMessage() {}
+
+func (x *AuthRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthRequestResponse.ProtoReflect.Descriptor instead.
+func (*AuthRequestResponse) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AuthRequestResponse) GetRequest() *structpb.Struct {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type AuthReplyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The authorization ID provided by the 'auth' event.
+	AuthId int64 `protobuf:"varint,1,opt,name=auth_id,json=authId,proto3" json:"auth_id,omitempty"`
+	// Either 'yes' or 'no'. Defaults to 'yes'.
+	Reply string `protobuf:"bytes,2,opt,name=reply,proto3" json:"reply,omitempty"`
+	// An optional reason if the reply is 'no'.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The PIN code to pair with, for 'request-pincode' requests.
+	Pincode string `protobuf:"bytes,4,opt,name=pincode,proto3" json:"pincode,omitempty"`
+	// The 6-digit passkey to pair with, for 'request-passkey' requests.
+	Passkey *uint32 `protobuf:"varint,5,opt,name=passkey,proto3,oneof" json:"passkey,omitempty"`
+}
+
+func (x *AuthReplyRequest) Reset() {
+	*x = AuthReplyRequest{}
+	mi := &file_bluerestd_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthReplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthReplyRequest) ProtoMessage() {}
+
+func (x *AuthReplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthReplyRequest.ProtoReflect.Descriptor instead.
+func (*AuthReplyRequest) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AuthReplyRequest) GetAuthId() int64 {
+	if x != nil {
+		return x.AuthId
+	}
+	return 0
+}
+
+func (x *AuthReplyRequest) GetReply() string {
+	if x != nil {
+		return x.Reply
+	}
+	return ""
+}
+
+func (x *AuthReplyRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AuthReplyRequest) GetPincode() string {
+	if x != nil {
+		return x.Pincode
+	}
+	return ""
+}
+
+func (x *AuthReplyRequest) GetPasskey() uint32 {
+	if x != nil && x.Passkey != nil {
+		return *x.Passkey
+	}
+	return 0
+}
+
+type AuthRuleIDRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the rule.
+	RuleId string `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+}
+
+func (x *AuthRuleIDRequest) Reset() {
+	*x = AuthRuleIDRequest{}
+	mi := &file_bluerestd_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthRuleIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthRuleIDRequest) ProtoMessage() {}
+
+func (x *AuthRuleIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthRuleIDRequest.ProtoReflect.Descriptor instead.
+func (*AuthRuleIDRequest) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AuthRuleIDRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+type AuthRuleCreateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The rule, with the same structure as the JSON body of the REST API.
+	Rule *structpb.Struct `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	// The position to insert the rule at, starting from 1. By default, the rule is appended.
+	Position int32 `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+}
+
+func (x *AuthRuleCreateRequest) Reset() {
+	*x = AuthRuleCreateRequest{}
+	mi := &file_bluerestd_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthRuleCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthRuleCreateRequest) ProtoMessage() {}
+
+func (x *AuthRuleCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthRuleCreateRequest.ProtoReflect.Descriptor instead.
+func (*AuthRuleCreateRequest) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AuthRuleCreateRequest) GetRule() *structpb.Struct {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *AuthRuleCreateRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type AuthRuleUpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the rule.
+	RuleId string `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// The rule, with the same structure as the JSON body of the REST API.
+	Rule *structpb.Struct `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+}
+
+func (x *AuthRuleUpdateRequest) Reset() {
+	*x = AuthRuleUpdateRequest{}
+	mi := &file_bluerestd_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthRuleUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthRuleUpdateRequest) ProtoMessage() {}
+
+func (x *AuthRuleUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthRuleUpdateRequest.ProtoReflect.Descriptor instead.
+func (*AuthRuleUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AuthRuleUpdateRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *AuthRuleUpdateRequest) GetRule() *structpb.Struct {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type AuthRulesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules []*structpb.Struct `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *AuthRulesResponse) Reset() {
+	*x = AuthRulesResponse{}
+	mi := &file_bluerestd_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthRulesResponse) ProtoMessage() {}
+
+func (x *AuthRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthRulesResponse.ProtoReflect.Descriptor instead.
+func (*AuthRulesResponse) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AuthRulesResponse) GetRules() []*structpb.Struct {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type AuthRuleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rule *structpb.Struct `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+}
+
+func (x *AuthRuleResponse) Reset() {
+	*x = AuthRuleResponse{}
+	mi := &file_bluerestd_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthRuleResponse) ProtoMessage() {}
+
+func (x *AuthRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthRuleResponse.ProtoReflect.Descriptor instead.
+func (*AuthRuleResponse) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AuthRuleResponse) GetRule() *structpb.Struct {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type InfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Info *structpb.Struct `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *InfoResponse) Reset() {
+	*x = InfoResponse{}
+	mi := &file_bluerestd_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfoResponse) ProtoMessage() {}
+
+func (x *InfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfoResponse.ProtoReflect.Descriptor instead.
+func (*InfoResponse) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *InfoResponse) GetInfo() *structpb.Struct {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type PresenceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only fetch devices that are present.
+	Present bool `protobuf:"varint,1,opt,name=present,proto3" json:"present,omitempty"`
+}
+
+func (x *PresenceRequest) Reset() {
+	*x = PresenceRequest{}
+	mi := &file_bluerestd_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresenceRequest) ProtoMessage() {}
+
+func (x *PresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresenceRequest.ProtoReflect.Descriptor instead.
+func (*PresenceRequest) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PresenceRequest) GetPresent() bool {
+	if x != nil {
+		return x.Present
+	}
+	return false
+}
+
+type PresenceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Devices []*structpb.Struct `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+}
+
+func (x *PresenceResponse) Reset() {
+	*x = PresenceResponse{}
+	mi := &file_bluerestd_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresenceResponse) ProtoMessage() {}
+
+func (x *PresenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresenceResponse.ProtoReflect.Descriptor instead.
+func (*PresenceResponse) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PresenceResponse) GetDevices() []*structpb.Struct {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type JournalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only return entries recorded at or after this time (RFC 3339).
+	Since string `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	// Only return entries recorded at or before this time (RFC 3339).
+	Until string `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"`
+	// Only return entries of the specified types. Use 'call' for API calls.
+	Type []string `protobuf:"bytes,3,rep,name=type,proto3" json:"type,omitempty"`
+	// Only return entries associated with the specified Bluetooth MAC addresses.
+	Address []string `protobuf:"bytes,4,rep,name=address,proto3" json:"address,omitempty"`
+	// The maximum number of entries to return.
+	Limit int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *JournalRequest) Reset() {
+	*x = JournalRequest{}
+	mi := &file_bluerestd_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JournalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalRequest) ProtoMessage() {}
+
+func (x *JournalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalRequest.ProtoReflect.Descriptor instead.
+func (*JournalRequest) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *JournalRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *JournalRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *JournalRequest) GetType() []string {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *JournalRequest) GetAddress() []string {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *JournalRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type JournalResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*structpb.Struct `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *JournalResponse) Reset() {
+	*x = JournalResponse{}
+	mi := &file_bluerestd_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JournalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalResponse) ProtoMessage() {}
+
+func (x *JournalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalResponse.ProtoReflect.Descriptor instead.
+func (*JournalResponse) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *JournalResponse) GetEntries() []*structpb.Struct {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only receive events with the specified event names.
+	Type []string `protobuf:"bytes,1,rep,name=type,proto3" json:"type,omitempty"`
+	// Only receive events associated with the specified Bluetooth MAC addresses.
+	Address []string `protobuf:"bytes,2,rep,name=address,proto3" json:"address,omitempty"`
+	// Only receive events with the specified event actions.
+	Action []string `protobuf:"bytes,3,rep,name=action,proto3" json:"action,omitempty"`
+	// If set, all buffered events after this event ID are replayed.
+	LastEventId uint64 `protobuf:"varint,4,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	// Register the client as an authorization agent, which answers the 'auth' events,
+	// for as long as it is subscribed (if 'auth' events are not filtered out).
+	Agent bool `protobuf:"varint,5,opt,name=agent,proto3" json:"agent,omitempty"`
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_bluerestd_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SubscribeRequest) GetType() []string {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetAddress() []string {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetAction() []string {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetLastEventId() uint64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+func (x *SubscribeRequest) GetAgent() bool {
+	if x != nil {
+		return x.Agent
+	}
+	return false
+}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The name of the event, for example 'device' or 'adapter'.
+	Event string          `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	Data  *structpb.Value `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_bluerestd_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_bluerestd_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_bluerestd_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Event) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *Event) GetData() *structpb.Value {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_bluerestd_proto protoreflect.FileDescriptor
+
+var file_bluerestd_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0c, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x1a,
+	0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2a, 0x0a, 0x0e, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x4d, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72,
+	0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0a,
+	0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65,
+	0x72, 0x74, 0x69, 0x65, 0x73, 0x22, 0x47, 0x0a, 0x10, 0x41, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x61, 0x64, 0x61,
+	0x70, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x61, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x22, 0x44,
+	0x0a, 0x0f, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x31, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x22, 0xf4, 0x01, 0x0a, 0x14, 0x41, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x70, 0x6f, 0x77, 0x65, 0x72,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x70, 0x6f, 0x77, 0x65,
+	0x72, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x70, 0x61, 0x69, 0x72, 0x61, 0x62,
+	0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x08, 0x70, 0x61, 0x69, 0x72,
+	0x61, 0x62, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x02, 0x52,
+	0x0c, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x88, 0x01, 0x01,
+	0x12, 0x21, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x03, 0x52, 0x09, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x65, 0x64, 0x42,
+	0x0b, 0x0a, 0x09, 0x5f, 0x70, 0x61, 0x69, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x0f, 0x0a, 0x0d,
+	0x5f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x0c, 0x0a,
+	0x0a, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x22, 0x8f, 0x01, 0x0a, 0x15,
+	0x41, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x65, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x61, 0x69, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x61, 0x69, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x64,
+	0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x22, 0x58, 0x0a,
+	0x19, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x22, 0x5a, 0x0a, 0x15, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x22, 0x53, 0x0a, 0x18, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66,
+	0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x22, 0x57, 0x0a, 0x19, 0x46, 0x69, 0x6c, 0x65,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x5f,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x22, 0x28, 0x0a, 0x0d, 0x41, 0x75, 0x74, 0x68, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x49, 0x64, 0x22, 0x4b, 0x0a, 0x14, 0x41,
+	0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x48, 0x0a, 0x13, 0x41, 0x75, 0x74, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x31, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x9e, 0x01, 0x0a, 0x10, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x49, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x69, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x70, 0x69, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x07, 0x70, 0x61, 0x73, 0x73,
+	0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x07, 0x70, 0x61, 0x73,
+	0x73, 0x6b, 0x65, 0x79, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x70, 0x61, 0x73, 0x73,
+	0x6b, 0x65, 0x79, 0x22, 0x2c, 0x0a, 0x11, 0x41, 0x75, 0x74, 0x68, 0x52, 0x75, 0x6c, 0x65, 0x49,
+	0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x75, 0x6c, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x75, 0x6c, 0x65, 0x49,
+	0x64, 0x22, 0x60, 0x0a, 0x15, 0x41, 0x75, 0x74, 0x68, 0x52, 0x75, 0x6c, 0x65, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x04, 0x72, 0x75,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x5d, 0x0a, 0x15, 0x41, 0x75, 0x74, 0x68, 0x52, 0x75, 0x6c, 0x65, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x72, 0x75, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x75, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x72, 0x75,
+	0x6c, 0x65, 0x22, 0x42, 0x0a, 0x11, 0x41, 0x75, 0x74, 0x68, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
+	0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x3f, 0x0a, 0x10, 0x41, 0x75, 0x74, 0x68, 0x52, 0x75,
+	0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x72, 0x75,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x22, 0x3b, 0x0a, 0x0c, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04,
+	0x69, 0x6e, 0x66, 0x6f, 0x22, 0x2b, 0x0a, 0x0f, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x73, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e,
+	0x74, 0x22, 0x45, 0x0a, 0x10, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
+	0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x0e, 0x4a, 0x6f, 0x75,
+	0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x69, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x44, 0x0a, 0x0f, 0x4a,
+	0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31,
+	0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x22, 0x92, 0x01, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0d,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x22, 0x59, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x32, 0xb5, 0x10, 0x0a, 0x09, 0x42, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x12,
+	0x42, 0x0a, 0x08, 0x41, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x1e, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x11, 0x41, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x50, 0x72,
+	0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72,
+	0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73,
+	0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x41, 0x64, 0x61, 0x70,
+	0x74, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x62, 0x6c, 0x75,
+	0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72,
+	0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0d, 0x41, 0x64, 0x61, 0x70, 0x74,
+	0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72,
+	0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x62,
+	0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x61, 0x70,
+	0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x52, 0x0a, 0x10, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x70, 0x65,
+	0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0a, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50,
+	0x61, 0x69, 0x72, 0x12, 0x1c, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x45, 0x0a, 0x0d, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x2e, 0x62, 0x6c, 0x75,
+	0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x48, 0x0a, 0x10, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x44, 0x0a, 0x0c, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x1c, 0x2e, 0x62, 0x6c, 0x75,
+	0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x57, 0x0a, 0x15, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50,
+	0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x62, 0x6c, 0x75, 0x65,
+	0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65,
+	0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x12, 0x4d, 0x65, 0x64,
+	0x69, 0x61, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12,
+	0x27, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x65, 0x64, 0x69, 0x61, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x4d, 0x0a, 0x0e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x12, 0x23, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x49, 0x0a, 0x11, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x64, 0x0a, 0x11, 0x46, 0x69,
+	0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12,
+	0x26, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x46,
+	0x69, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65,
+	0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x48, 0x0a, 0x10, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x53, 0x74, 0x6f, 0x70, 0x12, 0x1c, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4a, 0x0a, 0x0c, 0x41, 0x75,
+	0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x22, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0b, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x09, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x1e, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x44, 0x0a, 0x09, 0x41, 0x75,
+	0x74, 0x68, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x1f, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x75, 0x74, 0x68, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x4b, 0x0a, 0x08, 0x41, 0x75, 0x74, 0x68, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x1f, 0x2e, 0x62,
+	0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68,
+	0x52, 0x75, 0x6c, 0x65, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74,
+	0x68, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a,
+	0x0e, 0x41, 0x75, 0x74, 0x68, 0x52, 0x75, 0x6c, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12,
+	0x23, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x75, 0x74, 0x68, 0x52, 0x75, 0x6c, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0e, 0x41, 0x75, 0x74, 0x68, 0x52, 0x75, 0x6c, 0x65,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x23, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73,
+	0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x52, 0x75, 0x6c, 0x65, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x62, 0x6c,
+	0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x52,
+	0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0e, 0x41,
+	0x75, 0x74, 0x68, 0x52, 0x75, 0x6c, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x1f, 0x2e,
+	0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74,
+	0x68, 0x52, 0x75, 0x6c, 0x65, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3a, 0x0a, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73,
+	0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x49, 0x0a, 0x08, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1d,
+	0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72,
+	0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x65,
+	0x73, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a,
+	0x07, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x12, 0x1c, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72,
+	0x65, 0x73, 0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73,
+	0x74, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x12, 0x1e, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x72, 0x65, 0x73, 0x74, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x6c, 0x75, 0x65, 0x74, 0x75, 0x69, 0x74,
+	0x68, 0x2d, 0x6f, 0x72, 0x67, 0x2f, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2f, 0x72, 0x70, 0x63,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_bluerestd_proto_rawDescOnce sync.Once
+	file_bluerestd_proto_rawDescData = file_bluerestd_proto_rawDesc
+)
+
+func file_bluerestd_proto_rawDescGZIP() []byte {
+	file_bluerestd_proto_rawDescOnce.Do(func() {
+		file_bluerestd_proto_rawDescData = protoimpl.X.CompressGZIP(file_bluerestd_proto_rawDescData)
+	})
+	return file_bluerestd_proto_rawDescData
+}
+
+var file_bluerestd_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_bluerestd_proto_goTypes = []any{
+	(*AddressRequest)(nil),            // 0: bluerestd.v1.AddressRequest
+	(*PropertiesResponse)(nil),        // 1: bluerestd.v1.PropertiesResponse
+	(*AdaptersResponse)(nil),          // 2: bluerestd.v1.AdaptersResponse
+	(*DevicesResponse)(nil),           // 3: bluerestd.v1.DevicesResponse
+	(*AdapterStatesRequest)(nil),      // 4: bluerestd.v1.AdapterStatesRequest
+	(*AdapterStatesResponse)(nil),     // 5: bluerestd.v1.AdapterStatesResponse
+	(*MediaPlayerControlRequest)(nil), // 6: bluerestd.v1.MediaPlayerControlRequest
+	(*NetworkConnectRequest)(nil),     // 7: bluerestd.v1.NetworkConnectRequest
+	(*FileTransferStartRequest)(nil),  // 8: bluerestd.v1.FileTransferStartRequest
+	(*FileTransferStartResponse)(nil), // 9: bluerestd.v1.FileTransferStartResponse
+	(*AuthIDRequest)(nil),             // 10: bluerestd.v1.AuthIDRequest
+	(*AuthRequestsResponse)(nil),      // 11: bluerestd.v1.AuthRequestsResponse
+	(*AuthRequestResponse)(nil),       // 12: bluerestd.v1.AuthRequestResponse
+	(*AuthReplyRequest)(nil),          // 13: bluerestd.v1.AuthReplyRequest
+	(*AuthRuleIDRequest)(nil),         // 14: bluerestd.v1.AuthRuleIDRequest
+	(*AuthRuleCreateRequest)(nil),     // 15: bluerestd.v1.AuthRuleCreateRequest
+	(*AuthRuleUpdateRequest)(nil),     // 16: bluerestd.v1.AuthRuleUpdateRequest
+	(*AuthRulesResponse)(nil),         // 17: bluerestd.v1.AuthRulesResponse
+	(*AuthRuleResponse)(nil),          // 18: bluerestd.v1.AuthRuleResponse
+	(*InfoResponse)(nil),              // 19: bluerestd.v1.InfoResponse
+	(*PresenceRequest)(nil),           // 20: bluerestd.v1.PresenceRequest
+	(*PresenceResponse)(nil),          // 21: bluerestd.v1.PresenceResponse
+	(*JournalRequest)(nil),            // 22: bluerestd.v1.JournalRequest
+	(*JournalResponse)(nil),           // 23: bluerestd.v1.JournalResponse
+	(*SubscribeRequest)(nil),          // 24: bluerestd.v1.SubscribeRequest
+	(*Event)(nil),                     // 25: bluerestd.v1.Event
+	(*structpb.Struct)(nil),           // 26: google.protobuf.Struct
+	(*structpb.Value)(nil),            // 27: google.protobuf.Value
+	(*emptypb.Empty)(nil),             // 28: google.protobuf.Empty
+}
+var file_bluerestd_proto_depIdxs = []int32{
+	26, // 0: bluerestd.v1.PropertiesResponse.properties:type_name -> google.protobuf.Struct
+	26, // 1: bluerestd.v1.AdaptersResponse.adapters:type_name -> google.protobuf.Struct
+	26, // 2: bluerestd.v1.DevicesResponse.devices:type_name -> google.protobuf.Struct
+	26, // 3: bluerestd.v1.FileTransferStartResponse.queued_files:type_name -> google.protobuf.Struct
+	26, // 4: bluerestd.v1.AuthRequestsResponse.requests:type_name -> google.protobuf.Struct
+	26, // 5: bluerestd.v1.AuthRequestResponse.request:type_name -> google.protobuf.Struct
+	26, // 6: bluerestd.v1.AuthRuleCreateRequest.rule:type_name -> google.protobuf.Struct
+	26, // 7: bluerestd.v1.AuthRuleUpdateRequest.rule:type_name -> google.protobuf.Struct
+	26, // 8: bluerestd.v1.AuthRulesResponse.rules:type_name -> google.protobuf.Struct
+	26, // 9: bluerestd.v1.AuthRuleResponse.rule:type_name -> google.protobuf.Struct
+	26, // 10: bluerestd.v1.InfoResponse.info:type_name -> google.protobuf.Struct
+	26, // 11: bluerestd.v1.PresenceResponse.devices:type_name -> google.protobuf.Struct
+	26, // 12: bluerestd.v1.JournalResponse.entries:type_name -> google.protobuf.Struct
+	27, // 13: bluerestd.v1.Event.data:type_name -> google.protobuf.Value
+	28, // 14: bluerestd.v1.Bluerestd.Adapters:input_type -> google.protobuf.Empty
+	0,  // 15: bluerestd.v1.Bluerestd.AdapterProperties:input_type -> bluerestd.v1.AddressRequest
+	0,  // 16: bluerestd.v1.Bluerestd.AdapterDevices:input_type -> bluerestd.v1.AddressRequest
+	4,  // 17: bluerestd.v1.Bluerestd.AdapterStates:input_type -> bluerestd.v1.AdapterStatesRequest
+	0,  // 18: bluerestd.v1.Bluerestd.DeviceProperties:input_type -> bluerestd.v1.AddressRequest
+	0,  // 19: bluerestd.v1.Bluerestd.DevicePair:input_type -> bluerestd.v1.AddressRequest
+	0,  // 20: bluerestd.v1.Bluerestd.DeviceConnect:input_type -> bluerestd.v1.AddressRequest
+	0,  // 21: bluerestd.v1.Bluerestd.DeviceDisconnect:input_type -> bluerestd.v1.AddressRequest
+	0,  // 22: bluerestd.v1.Bluerestd.DeviceRemove:input_type -> bluerestd.v1.AddressRequest
+	0,  // 23: bluerestd.v1.Bluerestd.MediaPlayerProperties:input_type -> bluerestd.v1.AddressRequest
+	6,  // 24: bluerestd.v1.Bluerestd.MediaPlayerControl:input_type -> bluerestd.v1.MediaPlayerControlRequest
+	7,  // 25: bluerestd.v1.Bluerestd.NetworkConnect:input_type -> bluerestd.v1.NetworkConnectRequest
+	0,  // 26: bluerestd.v1.Bluerestd.NetworkDisconnect:input_type -> bluerestd.v1.AddressRequest
+	8,  // 27: bluerestd.v1.Bluerestd.FileTransferStart:input_type -> bluerestd.v1.FileTransferStartRequest
+	0,  // 28: bluerestd.v1.Bluerestd.FileTransferStop:input_type -> bluerestd.v1.AddressRequest
+	28, // 29: bluerestd.v1.Bluerestd.AuthRequests:input_type -> google.protobuf.Empty
+	10, // 30: bluerestd.v1.Bluerestd.AuthRequest:input_type -> bluerestd.v1.AuthIDRequest
+	13, // 31: bluerestd.v1.Bluerestd.AuthReply:input_type -> bluerestd.v1.AuthReplyRequest
+	28, // 32: bluerestd.v1.Bluerestd.AuthRules:input_type -> google.protobuf.Empty
+	14, // 33: bluerestd.v1.Bluerestd.AuthRule:input_type -> bluerestd.v1.AuthRuleIDRequest
+	15, // 34: bluerestd.v1.Bluerestd.AuthRuleCreate:input_type -> bluerestd.v1.AuthRuleCreateRequest
+	16, // 35: bluerestd.v1.Bluerestd.AuthRuleUpdate:input_type -> bluerestd.v1.AuthRuleUpdateRequest
+	14, // 36: bluerestd.v1.Bluerestd.AuthRuleDelete:input_type -> bluerestd.v1.AuthRuleIDRequest
+	28, // 37: bluerestd.v1.Bluerestd.Info:input_type -> google.protobuf.Empty
+	20, // 38: bluerestd.v1.Bluerestd.Presence:input_type -> bluerestd.v1.PresenceRequest
+	22, // 39: bluerestd.v1.Bluerestd.Journal:input_type -> bluerestd.v1.JournalRequest
+	24, // 40: bluerestd.v1.Bluerestd.Subscribe:input_type -> bluerestd.v1.SubscribeRequest
+	2,  // 41: bluerestd.v1.Bluerestd.Adapters:output_type -> bluerestd.v1.AdaptersResponse
+	1,  // 42: bluerestd.v1.Bluerestd.AdapterProperties:output_type -> bluerestd.v1.PropertiesResponse
+	3,  // 43: bluerestd.v1.Bluerestd.AdapterDevices:output_type -> bluerestd.v1.DevicesResponse
+	5,  // 44: bluerestd.v1.Bluerestd.AdapterStates:output_type -> bluerestd.v1.AdapterStatesResponse
+	1,  // 45: bluerestd.v1.Bluerestd.DeviceProperties:output_type -> bluerestd.v1.PropertiesResponse
+	28, // 46: bluerestd.v1.Bluerestd.DevicePair:output_type -> google.protobuf.Empty
+	28, // 47: bluerestd.v1.Bluerestd.DeviceConnect:output_type -> google.protobuf.Empty
+	28, // 48: bluerestd.v1.Bluerestd.DeviceDisconnect:output_type -> google.protobuf.Empty
+	28, // 49: bluerestd.v1.Bluerestd.DeviceRemove:output_type -> google.protobuf.Empty
+	1,  // 50: bluerestd.v1.Bluerestd.MediaPlayerProperties:output_type -> bluerestd.v1.PropertiesResponse
+	28, // 51: bluerestd.v1.Bluerestd.MediaPlayerControl:output_type -> google.protobuf.Empty
+	28, // 52: bluerestd.v1.Bluerestd.NetworkConnect:output_type -> google.protobuf.Empty
+	28, // 53: bluerestd.v1.Bluerestd.NetworkDisconnect:output_type -> google.protobuf.Empty
+	9,  // 54: bluerestd.v1.Bluerestd.FileTransferStart:output_type -> bluerestd.v1.FileTransferStartResponse
+	28, // 55: bluerestd.v1.Bluerestd.FileTransferStop:output_type -> google.protobuf.Empty
+	11, // 56: bluerestd.v1.Bluerestd.AuthRequests:output_type -> bluerestd.v1.AuthRequestsResponse
+	12, // 57: bluerestd.v1.Bluerestd.AuthRequest:output_type -> bluerestd.v1.AuthRequestResponse
+	28, // 58: bluerestd.v1.Bluerestd.AuthReply:output_type -> google.protobuf.Empty
+	17, // 59: bluerestd.v1.Bluerestd.AuthRules:output_type -> bluerestd.v1.AuthRulesResponse
+	18, // 60: bluerestd.v1.Bluerestd.AuthRule:output_type -> bluerestd.v1.AuthRuleResponse
+	18, // 61: bluerestd.v1.Bluerestd.AuthRuleCreate:output_type -> bluerestd.v1.AuthRuleResponse
+	18, // 62: bluerestd.v1.Bluerestd.AuthRuleUpdate:output_type -> bluerestd.v1.AuthRuleResponse
+	28, // 63: bluerestd.v1.Bluerestd.AuthRuleDelete:output_type -> google.protobuf.Empty
+	19, // 64: bluerestd.v1.Bluerestd.Info:output_type -> bluerestd.v1.InfoResponse
+	21, // 65: bluerestd.v1.Bluerestd.Presence:output_type -> bluerestd.v1.PresenceResponse
+	23, // 66: bluerestd.v1.Bluerestd.Journal:output_type -> bluerestd.v1.JournalResponse
+	25, // 67: bluerestd.v1.Bluerestd.Subscribe:output_type -> bluerestd.v1.Event
+	41, // [41:68] is the sub-list for method output_type
+	14, // [14:41] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_bluerestd_proto_init() }
+func file_bluerestd_proto_init() {
+	if File_bluerestd_proto != nil {
+		return
+	}
+	file_bluerestd_proto_msgTypes[4].OneofWrappers = []any{}
+	file_bluerestd_proto_msgTypes[13].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bluerestd_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bluerestd_proto_goTypes,
+		DependencyIndexes: file_bluerestd_proto_depIdxs,
+		MessageInfos:      file_bluerestd_proto_msgTypes,
+	}.Build()
+	File_bluerestd_proto = out.File
+	file_bluerestd_proto_rawDesc = nil
+	file_bluerestd_proto_goTypes = nil
+	file_bluerestd_proto_depIdxs = nil
+}
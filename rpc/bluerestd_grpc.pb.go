@@ -0,0 +1,1186 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: bluerestd.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Bluerestd_Adapters_FullMethodName              = "/bluerestd.v1.Bluerestd/Adapters"
+	Bluerestd_AdapterProperties_FullMethodName     = "/bluerestd.v1.Bluerestd/AdapterProperties"
+	Bluerestd_AdapterDevices_FullMethodName        = "/bluerestd.v1.Bluerestd/AdapterDevices"
+	Bluerestd_AdapterStates_FullMethodName         = "/bluerestd.v1.Bluerestd/AdapterStates"
+	Bluerestd_DeviceProperties_FullMethodName      = "/bluerestd.v1.Bluerestd/DeviceProperties"
+	Bluerestd_DevicePair_FullMethodName            = "/bluerestd.v1.Bluerestd/DevicePair"
+	Bluerestd_DeviceConnect_FullMethodName         = "/bluerestd.v1.Bluerestd/DeviceConnect"
+	Bluerestd_DeviceDisconnect_FullMethodName      = "/bluerestd.v1.Bluerestd/DeviceDisconnect"
+	Bluerestd_DeviceRemove_FullMethodName          = "/bluerestd.v1.Bluerestd/DeviceRemove"
+	Bluerestd_MediaPlayerProperties_FullMethodName = "/bluerestd.v1.Bluerestd/MediaPlayerProperties"
+	Bluerestd_MediaPlayerControl_FullMethodName    = "/bluerestd.v1.Bluerestd/MediaPlayerControl"
+	Bluerestd_NetworkConnect_FullMethodName        = "/bluerestd.v1.Bluerestd/NetworkConnect"
+	Bluerestd_NetworkDisconnect_FullMethodName     = "/bluerestd.v1.Bluerestd/NetworkDisconnect"
+	Bluerestd_FileTransferStart_FullMethodName     = "/bluerestd.v1.Bluerestd/FileTransferStart"
+	Bluerestd_FileTransferStop_FullMethodName      = "/bluerestd.v1.Bluerestd/FileTransferStop"
+	Bluerestd_AuthRequests_FullMethodName          = "/bluerestd.v1.Bluerestd/AuthRequests"
+	Bluerestd_AuthRequest_FullMethodName           = "/bluerestd.v1.Bluerestd/AuthRequest"
+	Bluerestd_AuthReply_FullMethodName             = "/bluerestd.v1.Bluerestd/AuthReply"
+	Bluerestd_AuthRules_FullMethodName             = "/bluerestd.v1.Bluerestd/AuthRules"
+	Bluerestd_AuthRule_FullMethodName              = "/bluerestd.v1.Bluerestd/AuthRule"
+	Bluerestd_AuthRuleCreate_FullMethodName        = "/bluerestd.v1.Bluerestd/AuthRuleCreate"
+	Bluerestd_AuthRuleUpdate_FullMethodName        = "/bluerestd.v1.Bluerestd/AuthRuleUpdate"
+	Bluerestd_AuthRuleDelete_FullMethodName        = "/bluerestd.v1.Bluerestd/AuthRuleDelete"
+	Bluerestd_Info_FullMethodName                  = "/bluerestd.v1.Bluerestd/Info"
+	Bluerestd_Presence_FullMethodName              = "/bluerestd.v1.Bluerestd/Presence"
+	Bluerestd_Journal_FullMethodName               = "/bluerestd.v1.Bluerestd/Journal"
+	Bluerestd_Subscribe_FullMethodName             = "/bluerestd.v1.Bluerestd/Subscribe"
+)
+
+// BluerestdClient is the client API for Bluerestd service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Bluerestd mirrors the REST API. Each RPC calls the REST operation named in its
+// comment, so the same validation applies, and errors are mapped to gRPC status codes.
+// Properties of adapters, devices and other objects have the same structure as the
+// JSON responses of the REST API, and are therefore sent as structs.
+//
+// RPCs for operations that are not available (for example, the media player RPCs
+// if the platform does not support media players) return 'UNIMPLEMENTED'.
+type BluerestdClient interface {
+	// Adapters fetches all adapters ('adapters').
+	Adapters(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AdaptersResponse, error)
+	// AdapterProperties fetches the properties of an adapter ('adapter-properties').
+	AdapterProperties(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*PropertiesResponse, error)
+	// AdapterDevices fetches the devices associated with an adapter ('adapter-devices').
+	AdapterDevices(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*DevicesResponse, error)
+	// AdapterStates sets and fetches the states of an adapter ('adapter-states').
+	AdapterStates(ctx context.Context, in *AdapterStatesRequest, opts ...grpc.CallOption) (*AdapterStatesResponse, error)
+	// DeviceProperties fetches the properties of a device ('device-properties').
+	DeviceProperties(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*PropertiesResponse, error)
+	// DevicePair pairs with a device ('device-pair').
+	DevicePair(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// DeviceConnect connects to a device ('device-connect').
+	DeviceConnect(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// DeviceDisconnect disconnects from a device ('device-disconnect').
+	DeviceDisconnect(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// DeviceRemove removes a device ('device-remove').
+	DeviceRemove(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// MediaPlayerProperties fetches the properties of a device's media player ('device-media-player-properties').
+	MediaPlayerProperties(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*PropertiesResponse, error)
+	// MediaPlayerControl sends a control command to a device's media player ('device-media-player-controls').
+	MediaPlayerControl(ctx context.Context, in *MediaPlayerControlRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// NetworkConnect tethers to a device's internet connection ('device-network-connect').
+	NetworkConnect(ctx context.Context, in *NetworkConnectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// NetworkDisconnect disconnects from a device's internet connection ('device-network-disconnect').
+	NetworkDisconnect(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// FileTransferStart sends files to a device ('file-transfer-start').
+	FileTransferStart(ctx context.Context, in *FileTransferStartRequest, opts ...grpc.CallOption) (*FileTransferStartResponse, error)
+	// FileTransferStop stops all file transfers to a device ('file-transfer-stop').
+	FileTransferStop(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// AuthRequests fetches all authorization requests that are waiting for a reply ('auth-requests').
+	AuthRequests(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AuthRequestsResponse, error)
+	// AuthRequest fetches an authorization request that is waiting for a reply ('auth-request').
+	AuthRequest(ctx context.Context, in *AuthIDRequest, opts ...grpc.CallOption) (*AuthRequestResponse, error)
+	// AuthReply replies to an authorization request, with the PIN code or passkey
+	// for the 'request-pincode' and 'request-passkey' requests ('auth-reply').
+	AuthReply(ctx context.Context, in *AuthReplyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// AuthRules fetches all authorization rules, in the order that they are evaluated in ('auth-rules').
+	AuthRules(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AuthRulesResponse, error)
+	// AuthRule fetches an authorization rule ('auth-rule').
+	AuthRule(ctx context.Context, in *AuthRuleIDRequest, opts ...grpc.CallOption) (*AuthRuleResponse, error)
+	// AuthRuleCreate creates an authorization rule ('auth-rule-create').
+	AuthRuleCreate(ctx context.Context, in *AuthRuleCreateRequest, opts ...grpc.CallOption) (*AuthRuleResponse, error)
+	// AuthRuleUpdate replaces an authorization rule, and keeps its position ('auth-rule-update').
+	AuthRuleUpdate(ctx context.Context, in *AuthRuleUpdateRequest, opts ...grpc.CallOption) (*AuthRuleResponse, error)
+	// AuthRuleDelete deletes an authorization rule ('auth-rule-delete').
+	AuthRuleDelete(ctx context.Context, in *AuthRuleIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Info fetches information about the daemon and its platform ('info').
+	Info(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*InfoResponse, error)
+	// Presence fetches the presence state of all recently seen devices ('presence').
+	Presence(ctx context.Context, in *PresenceRequest, opts ...grpc.CallOption) (*PresenceResponse, error)
+	// Journal fetches entries from the journal, if it is enabled ('journal').
+	Journal(ctx context.Context, in *JournalRequest, opts ...grpc.CallOption) (*JournalResponse, error)
+	// Subscribe streams all events, with the same data as the '/events' stream.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type bluerestdClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBluerestdClient(cc grpc.ClientConnInterface) BluerestdClient {
+	return &bluerestdClient{cc}
+}
+
+func (c *bluerestdClient) Adapters(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AdaptersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdaptersResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_Adapters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) AdapterProperties(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*PropertiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PropertiesResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_AdapterProperties_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) AdapterDevices(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*DevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DevicesResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_AdapterDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) AdapterStates(ctx context.Context, in *AdapterStatesRequest, opts ...grpc.CallOption) (*AdapterStatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdapterStatesResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_AdapterStates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) DeviceProperties(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*PropertiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PropertiesResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_DeviceProperties_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) DevicePair(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bluerestd_DevicePair_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) DeviceConnect(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bluerestd_DeviceConnect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) DeviceDisconnect(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bluerestd_DeviceDisconnect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) DeviceRemove(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bluerestd_DeviceRemove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) MediaPlayerProperties(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*PropertiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PropertiesResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_MediaPlayerProperties_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) MediaPlayerControl(ctx context.Context, in *MediaPlayerControlRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bluerestd_MediaPlayerControl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) NetworkConnect(ctx context.Context, in *NetworkConnectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bluerestd_NetworkConnect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) NetworkDisconnect(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bluerestd_NetworkDisconnect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) FileTransferStart(ctx context.Context, in *FileTransferStartRequest, opts ...grpc.CallOption) (*FileTransferStartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileTransferStartResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_FileTransferStart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) FileTransferStop(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bluerestd_FileTransferStop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) AuthRequests(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AuthRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthRequestsResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_AuthRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) AuthRequest(ctx context.Context, in *AuthIDRequest, opts ...grpc.CallOption) (*AuthRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthRequestResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_AuthRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) AuthReply(ctx context.Context, in *AuthReplyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bluerestd_AuthReply_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) AuthRules(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AuthRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthRulesResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_AuthRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) AuthRule(ctx context.Context, in *AuthRuleIDRequest, opts ...grpc.CallOption) (*AuthRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthRuleResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_AuthRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) AuthRuleCreate(ctx context.Context, in *AuthRuleCreateRequest, opts ...grpc.CallOption) (*AuthRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthRuleResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_AuthRuleCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) AuthRuleUpdate(ctx context.Context, in *AuthRuleUpdateRequest, opts ...grpc.CallOption) (*AuthRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthRuleResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_AuthRuleUpdate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) AuthRuleDelete(ctx context.Context, in *AuthRuleIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Bluerestd_AuthRuleDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) Info(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*InfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InfoResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_Info_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) Presence(ctx context.Context, in *PresenceRequest, opts ...grpc.CallOption) (*PresenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PresenceResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_Presence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) Journal(ctx context.Context, in *JournalRequest, opts ...grpc.CallOption) (*JournalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JournalResponse)
+	err := c.cc.Invoke(ctx, Bluerestd_Journal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bluerestdClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Bluerestd_ServiceDesc.Streams[0], Bluerestd_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Bluerestd_SubscribeClient = grpc.ServerStreamingClient[Event]
+
+// BluerestdServer is the server API for Bluerestd service.
+// All implementations must embed UnimplementedBluerestdServer
+// for forward compatibility.
+//
+// Bluerestd mirrors the REST API. Each RPC calls the REST operation named in its
+// comment, so the same validation applies, and errors are mapped to gRPC status codes.
+// Properties of adapters, devices and other objects have the same structure as the
+// JSON responses of the REST API, and are therefore sent as structs.
+//
+// RPCs for operations that are not available (for example, the media player RPCs
+// if the platform does not support media players) return 'UNIMPLEMENTED'.
+type BluerestdServer interface {
+	// Adapters fetches all adapters ('adapters').
+	Adapters(context.Context, *emptypb.Empty) (*AdaptersResponse, error)
+	// AdapterProperties fetches the properties of an adapter ('adapter-properties').
+	AdapterProperties(context.Context, *AddressRequest) (*PropertiesResponse, error)
+	// AdapterDevices fetches the devices associated with an adapter ('adapter-devices').
+	AdapterDevices(context.Context, *AddressRequest) (*DevicesResponse, error)
+	// AdapterStates sets and fetches the states of an adapter ('adapter-states').
+	AdapterStates(context.Context, *AdapterStatesRequest) (*AdapterStatesResponse, error)
+	// DeviceProperties fetches the properties of a device ('device-properties').
+	DeviceProperties(context.Context, *AddressRequest) (*PropertiesResponse, error)
+	// DevicePair pairs with a device ('device-pair').
+	DevicePair(context.Context, *AddressRequest) (*emptypb.Empty, error)
+	// DeviceConnect connects to a device ('device-connect').
+	DeviceConnect(context.Context, *AddressRequest) (*emptypb.Empty, error)
+	// DeviceDisconnect disconnects from a device ('device-disconnect').
+	DeviceDisconnect(context.Context, *AddressRequest) (*emptypb.Empty, error)
+	// DeviceRemove removes a device ('device-remove').
+	DeviceRemove(context.Context, *AddressRequest) (*emptypb.Empty, error)
+	// MediaPlayerProperties fetches the properties of a device's media player ('device-media-player-properties').
+	MediaPlayerProperties(context.Context, *AddressRequest) (*PropertiesResponse, error)
+	// MediaPlayerControl sends a control command to a device's media player ('device-media-player-controls').
+	MediaPlayerControl(context.Context, *MediaPlayerControlRequest) (*emptypb.Empty, error)
+	// NetworkConnect tethers to a device's internet connection ('device-network-connect').
+	NetworkConnect(context.Context, *NetworkConnectRequest) (*emptypb.Empty, error)
+	// NetworkDisconnect disconnects from a device's internet connection ('device-network-disconnect').
+	NetworkDisconnect(context.Context, *AddressRequest) (*emptypb.Empty, error)
+	// FileTransferStart sends files to a device ('file-transfer-start').
+	FileTransferStart(context.Context, *FileTransferStartRequest) (*FileTransferStartResponse, error)
+	// FileTransferStop stops all file transfers to a device ('file-transfer-stop').
+	FileTransferStop(context.Context, *AddressRequest) (*emptypb.Empty, error)
+	// AuthRequests fetches all authorization requests that are waiting for a reply ('auth-requests').
+	AuthRequests(context.Context, *emptypb.Empty) (*AuthRequestsResponse, error)
+	// AuthRequest fetches an authorization request that is waiting for a reply ('auth-request').
+	AuthRequest(context.Context, *AuthIDRequest) (*AuthRequestResponse, error)
+	// AuthReply replies to an authorization request, with the PIN code or passkey
+	// for the 'request-pincode' and 'request-passkey' requests ('auth-reply').
+	AuthReply(context.Context, *AuthReplyRequest) (*emptypb.Empty, error)
+	// AuthRules fetches all authorization rules, in the order that they are evaluated in ('auth-rules').
+	AuthRules(context.Context, *emptypb.Empty) (*AuthRulesResponse, error)
+	// AuthRule fetches an authorization rule ('auth-rule').
+	AuthRule(context.Context, *AuthRuleIDRequest) (*AuthRuleResponse, error)
+	// AuthRuleCreate creates an authorization rule ('auth-rule-create').
+	AuthRuleCreate(context.Context, *AuthRuleCreateRequest) (*AuthRuleResponse, error)
+	// AuthRuleUpdate replaces an authorization rule, and keeps its position ('auth-rule-update').
+	AuthRuleUpdate(context.Context, *AuthRuleUpdateRequest) (*AuthRuleResponse, error)
+	// AuthRuleDelete deletes an authorization rule ('auth-rule-delete').
+	AuthRuleDelete(context.Context, *AuthRuleIDRequest) (*emptypb.Empty, error)
+	// Info fetches information about the daemon and its platform ('info').
+	Info(context.Context, *emptypb.Empty) (*InfoResponse, error)
+	// Presence fetches the presence state of all recently seen devices ('presence').
+	Presence(context.Context, *PresenceRequest) (*PresenceResponse, error)
+	// Journal fetches entries from the journal, if it is enabled ('journal').
+	Journal(context.Context, *JournalRequest) (*JournalResponse, error)
+	// Subscribe streams all events, with the same data as the '/events' stream.
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedBluerestdServer()
+}
+
+// UnimplementedBluerestdServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBluerestdServer struct{}
+
+func (UnimplementedBluerestdServer) Adapters(context.Context, *emptypb.Empty) (*AdaptersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Adapters not implemented")
+}
+func (UnimplementedBluerestdServer) AdapterProperties(context.Context, *AddressRequest) (*PropertiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdapterProperties not implemented")
+}
+func (UnimplementedBluerestdServer) AdapterDevices(context.Context, *AddressRequest) (*DevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdapterDevices not implemented")
+}
+func (UnimplementedBluerestdServer) AdapterStates(context.Context, *AdapterStatesRequest) (*AdapterStatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdapterStates not implemented")
+}
+func (UnimplementedBluerestdServer) DeviceProperties(context.Context, *AddressRequest) (*PropertiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeviceProperties not implemented")
+}
+func (UnimplementedBluerestdServer) DevicePair(context.Context, *AddressRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DevicePair not implemented")
+}
+func (UnimplementedBluerestdServer) DeviceConnect(context.Context, *AddressRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeviceConnect not implemented")
+}
+func (UnimplementedBluerestdServer) DeviceDisconnect(context.Context, *AddressRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeviceDisconnect not implemented")
+}
+func (UnimplementedBluerestdServer) DeviceRemove(context.Context, *AddressRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeviceRemove not implemented")
+}
+func (UnimplementedBluerestdServer) MediaPlayerProperties(context.Context, *AddressRequest) (*PropertiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MediaPlayerProperties not implemented")
+}
+func (UnimplementedBluerestdServer) MediaPlayerControl(context.Context, *MediaPlayerControlRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MediaPlayerControl not implemented")
+}
+func (UnimplementedBluerestdServer) NetworkConnect(context.Context, *NetworkConnectRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NetworkConnect not implemented")
+}
+func (UnimplementedBluerestdServer) NetworkDisconnect(context.Context, *AddressRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NetworkDisconnect not implemented")
+}
+func (UnimplementedBluerestdServer) FileTransferStart(context.Context, *FileTransferStartRequest) (*FileTransferStartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileTransferStart not implemented")
+}
+func (UnimplementedBluerestdServer) FileTransferStop(context.Context, *AddressRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileTransferStop not implemented")
+}
+func (UnimplementedBluerestdServer) AuthRequests(context.Context, *emptypb.Empty) (*AuthRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthRequests not implemented")
+}
+func (UnimplementedBluerestdServer) AuthRequest(context.Context, *AuthIDRequest) (*AuthRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthRequest not implemented")
+}
+func (UnimplementedBluerestdServer) AuthReply(context.Context, *AuthReplyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthReply not implemented")
+}
+func (UnimplementedBluerestdServer) AuthRules(context.Context, *emptypb.Empty) (*AuthRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthRules not implemented")
+}
+func (UnimplementedBluerestdServer) AuthRule(context.Context, *AuthRuleIDRequest) (*AuthRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthRule not implemented")
+}
+func (UnimplementedBluerestdServer) AuthRuleCreate(context.Context, *AuthRuleCreateRequest) (*AuthRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthRuleCreate not implemented")
+}
+func (UnimplementedBluerestdServer) AuthRuleUpdate(context.Context, *AuthRuleUpdateRequest) (*AuthRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthRuleUpdate not implemented")
+}
+func (UnimplementedBluerestdServer) AuthRuleDelete(context.Context, *AuthRuleIDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthRuleDelete not implemented")
+}
+func (UnimplementedBluerestdServer) Info(context.Context, *emptypb.Empty) (*InfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
+}
+func (UnimplementedBluerestdServer) Presence(context.Context, *PresenceRequest) (*PresenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Presence not implemented")
+}
+func (UnimplementedBluerestdServer) Journal(context.Context, *JournalRequest) (*JournalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Journal not implemented")
+}
+func (UnimplementedBluerestdServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedBluerestdServer) mustEmbedUnimplementedBluerestdServer() {}
+func (UnimplementedBluerestdServer) testEmbeddedByValue()                   {}
+
+// UnsafeBluerestdServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BluerestdServer will
+// result in compilation errors.
+type UnsafeBluerestdServer interface {
+	mustEmbedUnimplementedBluerestdServer()
+}
+
+func RegisterBluerestdServer(s grpc.ServiceRegistrar, srv BluerestdServer) {
+	// If the following call pancis, it indicates UnimplementedBluerestdServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Bluerestd_ServiceDesc, srv)
+}
+
+func _Bluerestd_Adapters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).Adapters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_Adapters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).Adapters(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_AdapterProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).AdapterProperties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_AdapterProperties_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).AdapterProperties(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_AdapterDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).AdapterDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_AdapterDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).AdapterDevices(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_AdapterStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdapterStatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).AdapterStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_AdapterStates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).AdapterStates(ctx, req.(*AdapterStatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_DeviceProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).DeviceProperties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_DeviceProperties_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).DeviceProperties(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_DevicePair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).DevicePair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_DevicePair_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).DevicePair(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_DeviceConnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).DeviceConnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_DeviceConnect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).DeviceConnect(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_DeviceDisconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).DeviceDisconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_DeviceDisconnect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).DeviceDisconnect(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_DeviceRemove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).DeviceRemove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_DeviceRemove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).DeviceRemove(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_MediaPlayerProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).MediaPlayerProperties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_MediaPlayerProperties_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).MediaPlayerProperties(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_MediaPlayerControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MediaPlayerControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).MediaPlayerControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_MediaPlayerControl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).MediaPlayerControl(ctx, req.(*MediaPlayerControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_NetworkConnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkConnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).NetworkConnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_NetworkConnect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).NetworkConnect(ctx, req.(*NetworkConnectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_NetworkDisconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).NetworkDisconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_NetworkDisconnect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).NetworkDisconnect(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_FileTransferStart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileTransferStartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).FileTransferStart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_FileTransferStart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).FileTransferStart(ctx, req.(*FileTransferStartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_FileTransferStop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).FileTransferStop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_FileTransferStop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).FileTransferStop(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_AuthRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).AuthRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_AuthRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).AuthRequests(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_AuthRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).AuthRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_AuthRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).AuthRequest(ctx, req.(*AuthIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_AuthReply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthReplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).AuthReply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_AuthReply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).AuthReply(ctx, req.(*AuthReplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_AuthRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).AuthRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_AuthRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).AuthRules(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_AuthRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthRuleIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).AuthRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_AuthRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).AuthRule(ctx, req.(*AuthRuleIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_AuthRuleCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthRuleCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).AuthRuleCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_AuthRuleCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).AuthRuleCreate(ctx, req.(*AuthRuleCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_AuthRuleUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthRuleUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).AuthRuleUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_AuthRuleUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).AuthRuleUpdate(ctx, req.(*AuthRuleUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_AuthRuleDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthRuleIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).AuthRuleDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_AuthRuleDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).AuthRuleDelete(ctx, req.(*AuthRuleIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_Info_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).Info(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_Presence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PresenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).Presence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_Presence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).Presence(ctx, req.(*PresenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_Journal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JournalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BluerestdServer).Journal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bluerestd_Journal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BluerestdServer).Journal(ctx, req.(*JournalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bluerestd_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BluerestdServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Bluerestd_SubscribeServer = grpc.ServerStreamingServer[Event]
+
+// Bluerestd_ServiceDesc is the grpc.ServiceDesc for Bluerestd service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Bluerestd_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bluerestd.v1.Bluerestd",
+	HandlerType: (*BluerestdServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Adapters",
+			Handler:    _Bluerestd_Adapters_Handler,
+		},
+		{
+			MethodName: "AdapterProperties",
+			Handler:    _Bluerestd_AdapterProperties_Handler,
+		},
+		{
+			MethodName: "AdapterDevices",
+			Handler:    _Bluerestd_AdapterDevices_Handler,
+		},
+		{
+			MethodName: "AdapterStates",
+			Handler:    _Bluerestd_AdapterStates_Handler,
+		},
+		{
+			MethodName: "DeviceProperties",
+			Handler:    _Bluerestd_DeviceProperties_Handler,
+		},
+		{
+			MethodName: "DevicePair",
+			Handler:    _Bluerestd_DevicePair_Handler,
+		},
+		{
+			MethodName: "DeviceConnect",
+			Handler:    _Bluerestd_DeviceConnect_Handler,
+		},
+		{
+			MethodName: "DeviceDisconnect",
+			Handler:    _Bluerestd_DeviceDisconnect_Handler,
+		},
+		{
+			MethodName: "DeviceRemove",
+			Handler:    _Bluerestd_DeviceRemove_Handler,
+		},
+		{
+			MethodName: "MediaPlayerProperties",
+			Handler:    _Bluerestd_MediaPlayerProperties_Handler,
+		},
+		{
+			MethodName: "MediaPlayerControl",
+			Handler:    _Bluerestd_MediaPlayerControl_Handler,
+		},
+		{
+			MethodName: "NetworkConnect",
+			Handler:    _Bluerestd_NetworkConnect_Handler,
+		},
+		{
+			MethodName: "NetworkDisconnect",
+			Handler:    _Bluerestd_NetworkDisconnect_Handler,
+		},
+		{
+			MethodName: "FileTransferStart",
+			Handler:    _Bluerestd_FileTransferStart_Handler,
+		},
+		{
+			MethodName: "FileTransferStop",
+			Handler:    _Bluerestd_FileTransferStop_Handler,
+		},
+		{
+			MethodName: "AuthRequests",
+			Handler:    _Bluerestd_AuthRequests_Handler,
+		},
+		{
+			MethodName: "AuthRequest",
+			Handler:    _Bluerestd_AuthRequest_Handler,
+		},
+		{
+			MethodName: "AuthReply",
+			Handler:    _Bluerestd_AuthReply_Handler,
+		},
+		{
+			MethodName: "AuthRules",
+			Handler:    _Bluerestd_AuthRules_Handler,
+		},
+		{
+			MethodName: "AuthRule",
+			Handler:    _Bluerestd_AuthRule_Handler,
+		},
+		{
+			MethodName: "AuthRuleCreate",
+			Handler:    _Bluerestd_AuthRuleCreate_Handler,
+		},
+		{
+			MethodName: "AuthRuleUpdate",
+			Handler:    _Bluerestd_AuthRuleUpdate_Handler,
+		},
+		{
+			MethodName: "AuthRuleDelete",
+			Handler:    _Bluerestd_AuthRuleDelete_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _Bluerestd_Info_Handler,
+		},
+		{
+			MethodName: "Presence",
+			Handler:    _Bluerestd_Presence_Handler,
+		},
+		{
+			MethodName: "Journal",
+			Handler:    _Bluerestd_Journal_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Bluerestd_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bluerestd.proto",
+}
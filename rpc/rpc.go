@@ -0,0 +1,4 @@
+// Package rpc contains the gRPC service, which is generated from bluerestd.proto.
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative bluerestd.proto